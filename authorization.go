@@ -0,0 +1,152 @@
+package nursys
+
+import (
+	"maps"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AuthorizationToPracticeCode is the code in AuthorizationToPractice.AuthorizationToPracticeCode indicating
+// whether, and on what basis, a nurse may practice in a state.
+type AuthorizationToPracticeCode string
+
+// Authorization to practice codes from the appendix of the e-Notify File and API Specifications v3.1.2
+const (
+	AuthorizationCodeSingleState         AuthorizationToPracticeCode = "S" // Authorized by a single state license issued by the state.
+	AuthorizationCodeMultistateLicense   AuthorizationToPracticeCode = "M" // Authorized by a multistate license issued by the state as the home state.
+	AuthorizationCodeMultistatePrivilege AuthorizationToPracticeCode = "P" // Authorized by the multistate privilege of a license issued by another compact state.
+	AuthorizationCodeNotAuthorized       AuthorizationToPracticeCode = "N" // Not authorized to practice in the state.
+)
+
+// AuthorizationCodes maps authorization to practice codes to the basis on which they authorize a nurse to
+// practice. A nil AuthorizationCodes means DefaultAuthorizationCodes(); callers using another version of
+// the specification can pass their own.
+type AuthorizationCodes map[AuthorizationToPracticeCode]PracticeBasis
+
+var defaultAuthorizationCodes = AuthorizationCodes{
+	AuthorizationCodeSingleState:         PracticeBasisLicense,
+	AuthorizationCodeMultistateLicense:   PracticeBasisLicense,
+	AuthorizationCodeMultistatePrivilege: PracticeBasisMultistatePrivilege,
+	AuthorizationCodeNotAuthorized:       PracticeBasisNone,
+}
+
+// DefaultAuthorizationCodes returns a copy of the bases of the appendix codes, for callers that want to
+// extend them.
+func DefaultAuthorizationCodes() AuthorizationCodes {
+	return maps.Clone(defaultAuthorizationCodes)
+}
+
+// PracticeBasis is the basis on which a nurse is authorized to practice in a state.
+type PracticeBasis int
+
+// Practice bases
+const (
+	PracticeBasisNone                PracticeBasis = iota // Not authorized.
+	PracticeBasisLicense                                  // A license issued by the state itself, single state or multistate.
+	PracticeBasisMultistatePrivilege                      // The multistate privilege of a license issued by another Nurse Licensure Compact state.
+	PracticeBasisUnknown                                  // The code is not in the AuthorizationCodes used.
+)
+
+// String returns a short description of the basis.
+func (b PracticeBasis) String() string {
+	switch b {
+	case PracticeBasisLicense:
+		return "license"
+	case PracticeBasisMultistatePrivilege:
+		return "multistate privilege"
+	case PracticeBasisUnknown:
+		return "unknown"
+	}
+	return "none"
+}
+
+// Basis returns the basis of the authorization a, or PracticeBasisUnknown if its code is not in codes.
+func (codes AuthorizationCodes) Basis(a AuthorizationToPractice) PracticeBasis {
+	if codes == nil {
+		codes = defaultAuthorizationCodes
+	}
+	basis, ok := codes[AuthorizationToPracticeCode(strings.TrimSpace(string(a.AuthorizationToPracticeCode)))]
+	if !ok {
+		return PracticeBasisUnknown
+	}
+	return basis
+}
+
+// PracticeAuthorization summarizes whether a nurse may practice in a state.
+type PracticeAuthorization struct {
+	State      string                   // Two letter state abbreviation.
+	Family     LicenseType              // LicenseTypeRN or LicenseTypePN.
+	Authorized bool                     // Whether the nurse may practice in State now.
+	Basis      PracticeBasis            // Basis given by the authorization to practice code; PracticeBasisNone if the state is not listed.
+	Source     *AuthorizationToPractice // Entry of the Nurse Lookup response the summary is based on, nil if the state is not listed.
+	License    *NurseLookupLicense      // Active, unexpired license the authorization rests on, nil if there is none.
+}
+
+// CanPractice answers whether the nurse may practice in state at time now with a license of type licenseType,
+// and on what basis. The nurse is authorized if the authorization to practice code of the state grants a
+// basis according to codes, nil for DefaultAuthorizationCodes(), and the license it rests on is active and not expired at now (see
+// NurseLookupLicense.IsActive and IsExpired): the license issued by the state itself, or for a multistate
+// privilege a multistate license of another state. APRN license types are answered with the RN
+// authorization they practice under (see LicenseType.Family). A state that is not listed means the nurse is
+// not authorized there, and so does a code missing from codes, reported as PracticeBasisUnknown.
+func (r NurseLookupResponse) CanPractice(codes AuthorizationCodes, licenseType LicenseType, state string, now time.Time) PracticeAuthorization {
+	family := licenseType.Family()
+	state = strings.ToUpper(strings.TrimSpace(state))
+	summary := PracticeAuthorization{State: state, Family: family}
+	list := r.authorizationsToPractice(family)
+	for i, a := range list {
+		if !strings.EqualFold(a.StateAbbreviation, state) {
+			continue
+		}
+		summary.Basis = codes.Basis(a)
+		summary.Source = &list[i]
+		summary.License = r.practiceLicense(family, state, summary.Basis, now)
+		summary.Authorized = summary.License != nil
+		break
+	}
+	return summary
+}
+
+// PracticeStates returns the states in which the nurse is authorized to practice at time now with a license
+// of type licenseType, as answered by CanPractice, ordered by state.
+func (r NurseLookupResponse) PracticeStates(codes AuthorizationCodes, licenseType LicenseType, now time.Time) []PracticeAuthorization {
+	var states []PracticeAuthorization
+	for _, a := range r.authorizationsToPractice(licenseType.Family()) {
+		if pa := r.CanPractice(codes, licenseType, a.StateAbbreviation, now); pa.Authorized {
+			states = append(states, pa)
+		}
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].State < states[j].State })
+	return states
+}
+
+// practiceLicense returns the active, unexpired license of family that authorizes practice in state on basis.
+func (r NurseLookupResponse) practiceLicense(family LicenseType, state string, basis PracticeBasis, now time.Time) *NurseLookupLicense {
+	for i, l := range r.NurseLookupLicenses {
+		if l.LicenseType.Family() != family || !l.IsActive() || l.IsExpired(now) {
+			continue
+		}
+		issuer := strings.ToUpper(strings.TrimSpace(string(l.JurisdictionAbbreviation)))
+		if j, err := ParseJurisdiction(issuer); err == nil {
+			issuer = j.State()
+		}
+		issuedByState := issuer == state
+		switch {
+		case basis == PracticeBasisLicense && issuedByState,
+			basis == PracticeBasisMultistatePrivilege && !issuedByState && l.IsMultistate():
+			return &r.NurseLookupLicenses[i]
+		}
+	}
+	return nil
+}
+
+func (r NurseLookupResponse) authorizationsToPractice(family LicenseType) []AuthorizationToPractice {
+	switch family {
+	case LicenseTypeRN:
+		return r.NurseLookupRNAuthorizationsToPractice
+	case LicenseTypePN:
+		return r.NurseLookupPNAuthorizationsToPractice
+	}
+	return nil
+}
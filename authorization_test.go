@@ -0,0 +1,82 @@
+package nursys_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/connectRN/go-nursys"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CanPractice(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	var codes nursys.AuthorizationCodes // The appendix codes.
+	nurse := nursys.NurseLookupResponse{
+		NurseLookupLicenses: []nursys.NurseLookupLicense{
+			{LicenseType: nursys.LicenseTypeRN, JurisdictionAbbreviation: "TX", Active: "Yes", LicenseExpirationDate: "2025-01-31", CompactStatus: "Multistate"},
+			{LicenseType: nursys.LicenseTypeRN, JurisdictionAbbreviation: "CA-RN", Active: "Yes", LicenseExpirationDate: "2024-05-31"},
+			{LicenseType: nursys.LicenseTypePN, JurisdictionAbbreviation: "NY", Active: "Yes"},
+		},
+		NurseLookupRNAuthorizationsToPractice: []nursys.AuthorizationToPractice{
+			{StateAbbreviation: "TX", AuthorizationToPracticeCode: nursys.AuthorizationCodeMultistateLicense},
+			{StateAbbreviation: "FL", AuthorizationToPracticeCode: nursys.AuthorizationCodeMultistatePrivilege},
+			{StateAbbreviation: "NY", AuthorizationToPracticeCode: nursys.AuthorizationCodeNotAuthorized},
+			{StateAbbreviation: "CA", AuthorizationToPracticeCode: nursys.AuthorizationCodeSingleState},
+			{StateAbbreviation: "OH", AuthorizationToPracticeCode: "X", AuthorizationToPracticeDescription: "Authorized based on license"},
+		},
+		NurseLookupPNAuthorizationsToPractice: []nursys.AuthorizationToPractice{
+			{StateAbbreviation: "NY", AuthorizationToPracticeCode: nursys.AuthorizationCodeSingleState},
+		},
+	}
+
+	tx := nurse.CanPractice(codes, nursys.LicenseTypeRN, "tx", now)
+	assert.True(tx.Authorized)
+	assert.Equal(nursys.PracticeBasisLicense, tx.Basis)
+	assert.Equal("TX", tx.State)
+	assert.Equal(&nurse.NurseLookupRNAuthorizationsToPractice[0], tx.Source)
+	assert.Equal(&nurse.NurseLookupLicenses[0], tx.License)
+
+	fl := nurse.CanPractice(codes, nursys.LicenseTypeCNP, "FL", now) // APRNs practice on their RN authorization
+	assert.True(fl.Authorized)
+	assert.Equal(nursys.LicenseTypeRN, fl.Family)
+	assert.Equal("multistate privilege", fl.Basis.String())
+	assert.Equal(&nurse.NurseLookupLicenses[0], fl.License)
+
+	assert.False(nurse.CanPractice(codes, nursys.LicenseTypeRN, "NY", now).Authorized)
+	assert.True(nurse.CanPractice(codes, nursys.LicenseTypePN, "NY", now).Authorized)
+	assert.Equal(nursys.PracticeAuthorization{State: "WA", Family: nursys.LicenseTypeRN}, nurse.CanPractice(codes, nursys.LicenseTypeRN, "WA", now))
+
+	// The CA license expired.
+	ca := nurse.CanPractice(codes, nursys.LicenseTypeRN, "CA", now)
+	assert.False(ca.Authorized)
+	assert.Equal(nursys.PracticeBasisLicense, ca.Basis)
+	assert.Nil(ca.License)
+	assert.True(nurse.CanPractice(codes, nursys.LicenseTypeRN, "CA", now.AddDate(0, -1, 0)).Authorized)
+
+	// Codes missing from the map are not interpreted from the description.
+	oh := nurse.CanPractice(codes, nursys.LicenseTypeRN, "OH", now)
+	assert.False(oh.Authorized)
+	assert.Equal(nursys.PracticeBasisUnknown, oh.Basis)
+
+	// Callers can add codes to the defaults.
+	extended := nursys.DefaultAuthorizationCodes()
+	extended["X"] = nursys.PracticeBasisLicense
+	assert.Equal(nursys.PracticeBasisLicense, nurse.CanPractice(extended, nursys.LicenseTypeRN, "OH", now).Basis)
+	assert.Equal(nursys.PracticeBasisUnknown, nurse.CanPractice(nil, nursys.LicenseTypeRN, "OH", now).Basis)
+
+	// The privilege lapses with the home state license.
+	inactive := nurse
+	inactive.NurseLookupLicenses = []nursys.NurseLookupLicense{nurse.NurseLookupLicenses[0]}
+	inactive.NurseLookupLicenses[0].Active = "No"
+	assert.False(inactive.CanPractice(codes, nursys.LicenseTypeRN, "FL", now).Authorized)
+	assert.False(inactive.CanPractice(codes, nursys.LicenseTypeRN, "TX", now).Authorized)
+
+	var states []string
+	for _, pa := range nurse.PracticeStates(codes, nursys.LicenseTypeRN, now) {
+		states = append(states, pa.State)
+	}
+	assert.Equal([]string{"FL", "TX"}, states)
+}
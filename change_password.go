@@ -5,7 +5,7 @@ import "context"
 // ChangePassword changes an institution’s API password.
 func (c *nsHTTPClient) ChangePassword(ctx context.Context, request ChangePasswordSubmitRequestMessage) (ChangePasswordSubmitResponseMessage, error) {
 	var response ChangePasswordSubmitResponseMessage
-	err := c.invokeEndpoint(ctx, "POST", "/changepassword", request, &response)
+	err := c.invoke(ctx, &Call{Endpoint: EndpointChangePassword, Method: "POST", Path: "/changepassword", Request: request, Response: &response})
 	return response, err
 }
 
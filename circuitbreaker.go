@@ -0,0 +1,206 @@
+package nursys
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned, wrapped, for requests refused by an open CircuitBreaker.
+var ErrCircuitOpen = errors.New("nursys: circuit open")
+
+// Circuit breaker defaults
+const (
+	DefaultCircuitFailureThreshold = 5
+	DefaultCircuitOpenTimeout      = 30 * time.Second
+)
+
+// CircuitState is the state of the circuit of an endpoint.
+type CircuitState int
+
+// Circuit states
+const (
+	CircuitClosed   CircuitState = iota // Requests are sent.
+	CircuitOpen                         // Requests fail with ErrCircuitOpen.
+	CircuitHalfOpen                     // A single trial request is sent; other requests fail with ErrCircuitOpen.
+)
+
+// String returns the name of the state.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "closed"
+}
+
+// CircuitBreakerConfig configures a CircuitBreaker.
+type CircuitBreakerConfig struct {
+	FailureThreshold int           // Consecutive failures that open the circuit; DefaultCircuitFailureThreshold if 0.
+	OpenTimeout      time.Duration // Time an open circuit waits before letting a trial request through; DefaultCircuitOpenTimeout if 0.
+}
+
+// CircuitBreaker stops sending requests to an endpoint that keeps failing, so that callers fail fast
+// instead of waiting for timeouts. Each endpoint has its own circuit. A circuit opens after
+// FailureThreshold consecutive failures, and half-opens after OpenTimeout to let one trial request
+// through: the circuit closes if it succeeds and opens again if it fails.
+//
+// Failures are network errors, timeouts, and 429 or 5xx responses. Other error responses mean the
+// server is up and count as successes. Requests canceled by the caller are not counted, nor are requests
+// that started before the circuit last changed state. A request that reaches the deadline of the caller's
+// context after it was sent is a timeout, and counts as a failure.
+//
+// A CircuitBreaker is safe for concurrent use and can be shared by several clients.
+type CircuitBreaker struct {
+	cfg CircuitBreakerConfig
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+type circuit struct {
+	state    CircuitState
+	failures int       // Consecutive failures.
+	openedAt time.Time // When the circuit last opened.
+	trial    bool      // Whether the trial request of a half-open circuit is in progress.
+	// generation is incremented on every state change, so that the outcome of a request that started
+	// in an earlier state is ignored.
+	generation uint64
+}
+
+// NewCircuitBreaker creates a CircuitBreaker with all circuits closed.
+func NewCircuitBreaker(cfg CircuitBreakerConfig) *CircuitBreaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = DefaultCircuitFailureThreshold
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = DefaultCircuitOpenTimeout
+	}
+	return &CircuitBreaker{cfg: cfg, circuits: make(map[string]*circuit)}
+}
+
+// WithCircuitBreaker makes the client send requests through breaker.
+func WithCircuitBreaker(breaker *CircuitBreaker) ClientOption {
+	return func(c *nsHTTPClient) {
+		c.breaker = breaker
+	}
+}
+
+// State returns the state of the circuit of endpoint, see the Endpoint constants.
+func (cb *CircuitBreaker) State(endpoint string) CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.state(endpoint, time.Now())
+}
+
+// States returns the state of the circuit of every endpoint called so far, by endpoint name.
+func (cb *CircuitBreaker) States() map[string]CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	now := time.Now()
+	states := make(map[string]CircuitState, len(cb.circuits))
+	for endpoint := range cb.circuits {
+		states[endpoint] = cb.state(endpoint, now)
+	}
+	return states
+}
+
+// state returns the state of a circuit, moving it to half-open once its open timeout has passed.
+// The caller must hold cb.mu.
+func (cb *CircuitBreaker) state(endpoint string, now time.Time) CircuitState {
+	c, ok := cb.circuits[endpoint]
+	if !ok {
+		return CircuitClosed
+	}
+	if c.state == CircuitOpen && now.Sub(c.openedAt) >= cb.cfg.OpenTimeout {
+		c.state, c.trial = CircuitHalfOpen, false
+		c.generation++
+	}
+	return c.state
+}
+
+// allow reports whether a request to endpoint may be sent, and returns the generation of the circuit to
+// pass to record or cancel. Every allowed request must be followed by a call to one of them.
+func (cb *CircuitBreaker) allow(endpoint string) (uint64, error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	switch cb.state(endpoint, time.Now()) {
+	case CircuitOpen:
+		return 0, fmt.Errorf("%w: %s", ErrCircuitOpen, endpoint)
+	case CircuitHalfOpen:
+		c := cb.circuits[endpoint]
+		if c.trial {
+			return 0, fmt.Errorf("%w: %s", ErrCircuitOpen, endpoint)
+		}
+		c.trial = true
+	}
+	return cb.circuit(endpoint).generation, nil
+}
+
+// cancel releases an allowed request that was not sent or whose outcome says nothing about the server.
+func (cb *CircuitBreaker) cancel(endpoint string, generation uint64) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if c := cb.circuit(endpoint); c.generation == generation {
+		c.trial = false
+	}
+}
+
+// record updates the circuit of endpoint with the outcome of an allowed request.
+func (cb *CircuitBreaker) record(endpoint string, generation uint64, err error) {
+	if errors.Is(err, context.Canceled) {
+		cb.cancel(endpoint, generation)
+		return
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	c := cb.circuit(endpoint)
+	if c.generation != generation {
+		return
+	}
+	c.trial = false
+	if !isCircuitFailure(err) {
+		if c.state != CircuitClosed {
+			c.state = CircuitClosed
+			c.generation++
+		}
+		c.failures = 0
+		return
+	}
+	c.failures++
+	if c.state == CircuitHalfOpen || c.failures >= cb.cfg.FailureThreshold {
+		c.state, c.openedAt = CircuitOpen, time.Now()
+		c.generation++
+	}
+}
+
+// circuit returns the circuit of endpoint, creating it closed if needed. The caller must hold cb.mu.
+func (cb *CircuitBreaker) circuit(endpoint string) *circuit {
+	c, ok := cb.circuits[endpoint]
+	if !ok {
+		c = &circuit{}
+		cb.circuits[endpoint] = c
+	}
+	return c
+}
+
+// isCircuitFailure reports whether err means the server is unavailable.
+func isCircuitFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+	// A response that can't be decoded still came from a live server.
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	return !errors.As(err, &syntaxErr) && !errors.As(err, &typeErr)
+}
@@ -0,0 +1,159 @@
+package nursys_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/connectRN/go-nursys"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WithCircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+
+	var down atomic.Bool
+	var requests atomic.Int32
+	down.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		switch {
+		case down.Load():
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		case req.URL.Path == "/changepassword":
+			rw.WriteHeader(http.StatusBadRequest)
+		default:
+			rw.Write(submitResponseJSON)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	breaker := nursys.NewCircuitBreaker(nursys.CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: 50 * time.Millisecond})
+	client := nursys.New(server.URL, "acme", "1234!", nursys.WithCircuitBreaker(breaker))
+	lookup := func() error {
+		_, err := client.NurseLookup(ctx, nursys.NurseLookupSubmitRequestMessage{})
+		return err
+	}
+
+	// Two consecutive failures open the circuit, which then fails fast.
+	assert.Error(lookup())
+	assert.Equal(nursys.CircuitClosed, breaker.State(nursys.EndpointNurseLookup))
+	assert.Error(lookup())
+	assert.Equal(nursys.CircuitOpen, breaker.State(nursys.EndpointNurseLookup))
+	assert.ErrorIs(lookup(), nursys.ErrCircuitOpen)
+	assert.Equal(int32(2), requests.Load())
+
+	// Other endpoints have their own circuit.
+	_, err := client.GetNurseLookupResult(ctx, "tx")
+	assert.NotErrorIs(err, nursys.ErrCircuitOpen)
+	assert.Equal(map[string]nursys.CircuitState{
+		nursys.EndpointNurseLookup:          nursys.CircuitOpen,
+		nursys.EndpointGetNurseLookupResult: nursys.CircuitClosed,
+	}, breaker.States())
+
+	// After the open timeout a failing trial request opens the circuit again.
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(nursys.CircuitHalfOpen, breaker.State(nursys.EndpointNurseLookup))
+	assert.NotErrorIs(lookup(), nursys.ErrCircuitOpen)
+	assert.Equal(nursys.CircuitOpen, breaker.State(nursys.EndpointNurseLookup))
+
+	// A successful trial request closes it.
+	down.Store(false)
+	time.Sleep(60 * time.Millisecond)
+	assert.NoError(lookup())
+	assert.Equal(nursys.CircuitClosed, breaker.State(nursys.EndpointNurseLookup))
+
+	// Client errors mean the server is up.
+	for i := 0; i < 3; i++ {
+		_, err := client.ChangePassword(ctx, nursys.ChangePasswordSubmitRequestMessage{})
+		assert.NotErrorIs(err, nursys.ErrCircuitOpen)
+	}
+	assert.Equal(nursys.CircuitClosed, breaker.State(nursys.EndpointChangePassword))
+	assert.Equal("half-open", nursys.CircuitHalfOpen.String())
+}
+
+func Test_CircuitBreaker_IgnoredOutcomes(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Header.Get(nursys.HeaderUsername) {
+		case "slow":
+			started <- struct{}{}
+			select {
+			case <-release:
+			case <-req.Context().Done():
+				return
+			}
+			rw.Write(submitResponseJSON)
+		default:
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	breaker := nursys.NewCircuitBreaker(nursys.CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Hour})
+	slow := nursys.New(server.URL, "slow", "1234!", nursys.WithCircuitBreaker(breaker))
+	failing := nursys.New(server.URL, "acme", "1234!", nursys.WithCircuitBreaker(breaker))
+
+	// A request canceled by the caller is not a failure.
+	for i := 0; i < 2; i++ {
+		canceled, cancel := context.WithCancel(ctx)
+		done := make(chan error)
+		go func() {
+			_, err := slow.GetNurseLookupResult(canceled, "tx")
+			done <- err
+		}()
+		<-started
+		cancel()
+		assert.ErrorIs(<-done, context.Canceled)
+	}
+	assert.Equal(nursys.CircuitClosed, breaker.State(nursys.EndpointGetNurseLookupResult))
+
+	// A request that started before the circuit opened does not close it when it succeeds.
+	done := make(chan error)
+	go func() {
+		_, err := slow.GetNurseLookupResult(ctx, "tx")
+		done <- err
+	}()
+	<-started
+	for i := 0; i < 2; i++ {
+		_, err := failing.GetNurseLookupResult(ctx, "tx")
+		assert.Error(err)
+	}
+	assert.Equal(nursys.CircuitOpen, breaker.State(nursys.EndpointGetNurseLookupResult))
+	close(release)
+	assert.NoError(<-done)
+	assert.Equal(nursys.CircuitOpen, breaker.State(nursys.EndpointGetNurseLookupResult))
+}
+
+func Test_CircuitBreaker_DeadlineExceeded(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		<-release
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+
+	breaker := nursys.NewCircuitBreaker(nursys.CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Hour})
+	client := nursys.New(server.URL, "acme", "1234!", nursys.WithCircuitBreaker(breaker))
+
+	// A sent request past the deadline of the caller's context is a timeout, and so a failure.
+	for i := 0; i < 2; i++ {
+		short, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		_, err := client.GetNurseLookupResult(short, "tx")
+		cancel()
+		assert.ErrorIs(err, context.DeadlineExceeded)
+	}
+	assert.Equal(nursys.CircuitOpen, breaker.State(nursys.EndpointGetNurseLookupResult))
+}
@@ -3,10 +3,14 @@ package nursys
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"time"
 )
 
 const (
@@ -38,10 +42,19 @@ type Client interface {
 
 // Urban Airship HTTP API Client implementation
 type nsHTTPClient struct {
-	httpClient  *http.Client
-	username    string
-	pasword     string
-	endpointURL string
+	httpClient *http.Client
+	username   string
+	pasword    string
+	baseURL    *url.URL
+	configErr  error // Invalid configuration, returned by every request.
+	logger     *slog.Logger
+	middleware []Middleware
+	metrics    MetricsRecorder
+	tracer     Tracer
+	limiter    *RateLimiter
+	breaker    *CircuitBreaker
+	transport  transportConfig // Used to create httpClient when not given.
+	strict     bool            // Whether license types are validated, see WithStrictLicenseTypes.
 }
 
 // ClientOption are configuration functions that can be passed to New to configure the client.
@@ -51,40 +64,71 @@ type ClientOption func(c *nsHTTPClient)
 // For example:
 //
 //	conn := nursys.New("https://api.whatever/", "username", "password")
+//
+// If baseURL is invalid every request fails with the error; use NewClient to check it up front.
 func New(baseURL, username, password string, options ...ClientOption) Client {
 	client := nsHTTPClient{
-		endpointURL: baseURL,
-		username:    username,
-		pasword:     password,
+		username:  username,
+		pasword:   password,
+		transport: transportConfig{timeout: DefaultTimeout, minTLSVersion: tls.VersionTLS12},
 	}
+	client.baseURL, client.configErr = parseBaseURL(baseURL)
 	for _, opt := range options {
 		opt(&client)
 	}
 	if client.httpClient == nil {
-		client.httpClient = &http.Client{}
+		client.httpClient = client.transport.newHTTPClient()
 	}
 	return &client
 }
 
 // WithHTTPClient overrides the http.Client instance used by the Airship Client.
 // This is useful for unit tests of the client itself, but not much else.
+// The connection options WithTimeout, WithProxy, WithRootCAs, WithClientCertificate and WithMinTLSVersion
+// are ignored when it is given.
 func WithHTTPClient(httpClient *http.Client) ClientOption {
 	return func(c *nsHTTPClient) {
 		c.httpClient = httpClient
 	}
 }
 
+// StatusError is returned for requests answered with an HTTP status other than 200 OK or 202 Accepted.
+type StatusError struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("nursys: request returned %d: %s", e.StatusCode, e.Body)
+}
+
 // InvokeEndpoint invokes the airship API endpoint by sending <body> to <endpoint> using HTTP <method>.
 // The response body is discarded unless an error status is returned.
 func (cfg *nsHTTPClient) invokeEndpoint(ctx context.Context, method string, endpoint string, body interface{}, target interface{}) error {
+	_, err := cfg.do(ctx, method, endpoint, body, target)
+	return err
+}
+
+// do implements invokeEndpoint, also returning the HTTP status code of the response, or 0 if none was received.
+func (cfg *nsHTTPClient) do(ctx context.Context, method string, endpoint string, body interface{}, target interface{}) (status int, err error) {
+	start := time.Now()
+	defer func() { cfg.logRequest(ctx, method, endpoint, start, status, target, err) }()
+	if cfg.configErr != nil {
+		return 0, cfg.configErr
+	}
+
 	jsonStr, err := json.Marshal(body)
 	if err != nil {
-		return err
+		return 0, err
+	}
+	if body != nil {
+		cfg.logBody(ctx, "nursys request body", method, endpoint, jsonStr)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, cfg.endpointURL+endpoint, bytes.NewBuffer(jsonStr))
+	req, err := http.NewRequestWithContext(ctx, method, endpointURL(cfg.baseURL, endpoint), bytes.NewBuffer(jsonStr))
 	if err != nil {
-		return err
+		return 0, err
 	}
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("username", cfg.username) // The non-standard HTTP header in which to send the API username.
@@ -93,13 +137,22 @@ func (cfg *nsHTTPClient) invokeEndpoint(ctx context.Context, method string, endp
 	resp, err := cfg.httpClient.Do(req)
 	if err == nil {
 		defer resp.Body.Close()
+		status = resp.StatusCode
 		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
 			respBody, _ := io.ReadAll(resp.Body)
-			return fmt.Errorf("nursys: request returned %d: %s", resp.StatusCode, respBody)
+			cfg.logBody(ctx, "nursys response body", method, endpoint, respBody)
+			return status, &StatusError{StatusCode: resp.StatusCode, Header: resp.Header, Body: respBody}
+		} else if target != nil && cfg.debugEnabled(ctx) {
+			respBody, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return status, err
+			}
+			cfg.logBody(ctx, "nursys response body", method, endpoint, respBody)
+			return status, json.Unmarshal(respBody, target)
 		} else if target != nil {
-			return json.NewDecoder(resp.Body).Decode(target)
+			return status, json.NewDecoder(resp.Body).Decode(target)
 		}
 	}
 
-	return err
+	return status, err
 }
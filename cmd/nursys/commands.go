@@ -0,0 +1,419 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/connectRN/go-nursys"
+)
+
+// maxDocumentsPerCall is the maximum number of DocumentId values Nursys accepts in one Retrieve Documents call.
+const maxDocumentsPerCall = 5
+
+func runEnroll(ctx context.Context, c *cli, args []string) error {
+	return manageNurseList(ctx, c, "enroll", nursys.ActionCodeAdd, args)
+}
+
+func runRemove(ctx context.Context, c *cli, args []string) error {
+	return manageNurseList(ctx, c, "remove", nursys.ActionCodeRemove, args)
+}
+
+func manageNurseList(ctx context.Context, c *cli, name, action string, args []string) error {
+	fs := c.newFlagSet(name)
+	file := fs.String("file", "", "roster `file`, CSV (.csv) or JSON")
+	mappingFile := fs.String("mapping", "", "JSON `file` mapping API field names to CSV column headers")
+	batchSize := fs.Int("batch", nursys.DefaultBatchSize, "maximum nurses per submission")
+	wait := fs.Bool("wait", false, "wait for and print the results")
+	interval := fs.Duration("interval", nursys.DefaultPollInterval, "polling interval with -wait")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return usageError("-file is required")
+	}
+
+	requests, err := readRoster(c, *file, *mappingFile)
+	if err != nil {
+		return err
+	}
+	if len(requests) == 0 {
+		return errors.New("no nurses to submit")
+	}
+	for i := range requests {
+		requests[i].SubmissionActionCode = action
+	}
+
+	for len(requests) > 0 {
+		n := min(max(*batchSize, 1), len(requests))
+		batch := nursys.ManageNurseListSubmitRequestMessage{ManageNurseListRequests: requests[:n]}
+		requests = requests[n:]
+
+		resp, err := c.client.ManageNurseList(ctx, batch)
+		if err != nil {
+			return err
+		}
+		summary := fmt.Sprintf("%s %d nurses from %s", name, len(batch.ManageNurseListRequests), filepath.Base(*file))
+		if err := c.submitted("managenurselist", resp.Transaction, summary); err != nil {
+			return err
+		}
+		if !*wait || !resp.TransactionSuccessFlag {
+			if err := c.print(resp); err != nil {
+				return err
+			}
+			if err := check(resp.Transaction); err != nil {
+				return err
+			}
+			continue
+		}
+		result, err := nursys.PollManageNurseListResult(ctx, c.client, resp.TransactionID, *interval)
+		if err != nil {
+			return err
+		}
+		if err := c.untrackPending(resp.TransactionID); err != nil {
+			return err
+		}
+		if err := c.print(result); err != nil {
+			return err
+		}
+		if err := check(result.Transaction); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readRoster reads ManageNurseListRequest records from a CSV or JSON file. Invalid CSV rows are reported on
+// stderr and make the whole read fail, so that a partial roster is never submitted by accident.
+func readRoster(c *cli, path, mappingPath string) ([]nursys.ManageNurseListRequest, error) {
+	if !strings.EqualFold(filepath.Ext(path), ".csv") {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var requests []nursys.ManageNurseListRequest
+		if err := unmarshalList(b, "ManageNurseListRequests", &requests); err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+		return requests, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var mapping nursys.CSVColumnMapping
+	if mappingPath != "" {
+		b, err := os.ReadFile(mappingPath)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, &mapping); err != nil {
+			return nil, fmt.Errorf("reading %s: %w", mappingPath, err)
+		}
+	}
+	requests, rowErrs, err := nursys.NewCSVReader(f, mapping).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, rowErr := range rowErrs {
+		fmt.Fprintf(c.stderr, "%s: %v\n", path, rowErr)
+	}
+	if len(rowErrs) > 0 {
+		return nil, fmt.Errorf("%s: %d invalid rows", path, len(rowErrs))
+	}
+	return requests, nil
+}
+
+// unmarshalList decodes b into list. b may be a JSON array or a request message object holding the array in key.
+func unmarshalList(b []byte, key string, list interface{}) error {
+	b = bytes.TrimSpace(b)
+	if len(b) > 0 && b[0] == '{' {
+		var msg map[string]json.RawMessage
+		if err := json.Unmarshal(b, &msg); err != nil {
+			return err
+		}
+		b = msg[key]
+		if b == nil {
+			return fmt.Errorf("object has no %s", key)
+		}
+	}
+	return json.Unmarshal(b, list)
+}
+
+func runLookup(ctx context.Context, c *cli, args []string) error {
+	fs := c.newFlagSet("lookup")
+	file := fs.String("file", "", "JSON `file` with NurseLookupRequests")
+	var req nursys.NurseLookupRequest
+	fs.Func("jurisdiction", "jurisdiction abbreviation", func(s string) (err error) {
+		req.JurisdictionAbbreviation, err = nursys.ParseJurisdiction(s)
+		return err
+	})
+	fs.Func("type", "license type", func(s string) (err error) {
+		req.LicenseType, err = nursys.ParseLicenseType(s)
+		return err
+	})
+	fs.StringVar(&req.LicenseNumber, "number", "", "license number")
+	fs.StringVar(&req.NcsbnID, "ncsbn", "", "NCSBN ID")
+	fs.StringVar(&req.RecordID, "record", "", "record ID echoed back in the results")
+	wait := fs.Bool("wait", false, "wait for and print the results")
+	interval := fs.Duration("interval", nursys.DefaultPollInterval, "polling interval with -wait")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	var msg nursys.NurseLookupSubmitRequestMessage
+	switch {
+	case *file != "" && req != nursys.NurseLookupRequest{}:
+		return usageError("use either -file or the individual license flags")
+	case *file != "":
+		b, err := os.ReadFile(*file)
+		if err != nil {
+			return err
+		}
+		if err := unmarshalList(b, "NurseLookupRequests", &msg.NurseLookupRequests); err != nil {
+			return fmt.Errorf("reading %s: %w", *file, err)
+		}
+	case req != nursys.NurseLookupRequest{}:
+		msg.NurseLookupRequests = []nursys.NurseLookupRequest{req}
+	default:
+		return usageError("-file or license flags are required")
+	}
+
+	resp, err := c.client.NurseLookup(ctx, msg)
+	if err != nil {
+		return err
+	}
+	if err := c.submitted("nurselookup", resp.Transaction, fmt.Sprintf("lookup %d nurses", len(msg.NurseLookupRequests))); err != nil {
+		return err
+	}
+	if !*wait || !resp.TransactionSuccessFlag {
+		if err := c.print(resp); err != nil {
+			return err
+		}
+		return check(resp.Transaction)
+	}
+	result, err := nursys.PollNurseLookupResult(ctx, c.client, resp.TransactionID, *interval)
+	if err != nil {
+		return err
+	}
+	if err := c.untrackPending(resp.TransactionID); err != nil {
+		return err
+	}
+	if err := c.print(result); err != nil {
+		return err
+	}
+	return check(result.Transaction)
+}
+
+func runNotifications(ctx context.Context, c *cli, args []string) error {
+	fs := c.newFlagSet("notifications")
+	start := fs.String("start", "", "start `date` (YYYY-MM-DD)")
+	end := fs.String("end", time.Now().Format(time.DateOnly), "end `date` (YYYY-MM-DD)")
+	wait := fs.Bool("wait", false, "wait for and print the results")
+	interval := fs.Duration("interval", nursys.DefaultPollInterval, "polling interval with -wait")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *start == "" {
+		return usageError("-start is required")
+	}
+	for _, d := range []string{*start, *end} {
+		if _, err := time.Parse(time.DateOnly, d); err != nil {
+			return usageError(fmt.Sprintf("invalid date %q", d))
+		}
+	}
+
+	resp, err := c.client.NotificationLookup(ctx, nursys.NotificationLookupSubmitRequestMessage{StartDate: *start, EndDate: *end})
+	if err != nil {
+		return err
+	}
+	if err := c.submitted("notificationlookup", resp.Transaction, "notifications "+*start+" to "+*end); err != nil {
+		return err
+	}
+	if !*wait || !resp.TransactionSuccessFlag {
+		if err := c.print(resp); err != nil {
+			return err
+		}
+		return check(resp.Transaction)
+	}
+	result, err := nursys.PollNotificationLookupResult(ctx, c.client, resp.TransactionID, *interval)
+	if err != nil {
+		return err
+	}
+	if err := c.untrackPending(resp.TransactionID); err != nil {
+		return err
+	}
+	if err := c.print(result); err != nil {
+		return err
+	}
+	return check(result.Transaction)
+}
+
+func runDocuments(ctx context.Context, c *cli, args []string) error {
+	fs := c.newFlagSet("documents")
+	out := fs.String("out", "", "`directory` to save the document contents in")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	ids := fs.Args()
+	if len(ids) == 0 {
+		return usageError("at least one document ID is required")
+	}
+
+	for len(ids) > 0 {
+		n := min(maxDocumentsPerCall, len(ids))
+		resp, err := c.client.RetrieveDocuments(ctx, ids[:n])
+		ids = ids[n:]
+		if err != nil {
+			return err
+		}
+		if *out != "" {
+			for i, doc := range resp.Documents {
+				if !doc.SuccessFlag {
+					continue
+				}
+				if err := saveDocument(*out, doc); err != nil {
+					return err
+				}
+				resp.Documents[i].DocumentContents = "" // Saved to disk, don't print it too
+			}
+		}
+		if err := c.print(resp); err != nil {
+			return err
+		}
+		if err := check(resp.Transaction); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveDocument writes the document contents to dir. The contents are base64 decoded if possible.
+// The document ID and name come from the server, so they must not lead outside dir.
+func saveDocument(dir string, doc nursys.RetrieveDocumentResponse) error {
+	if doc.DocumentID == "" || doc.DocumentID == "." || doc.DocumentID == ".." || strings.ContainsAny(doc.DocumentID, `/\`) {
+		return fmt.Errorf("document ID %q is not a valid file name", doc.DocumentID)
+	}
+	contents := []byte(doc.DocumentContents)
+	if decoded, err := base64.StdEncoding.DecodeString(doc.DocumentContents); err == nil {
+		contents = decoded
+	}
+	name := filepath.Base(strings.ReplaceAll(doc.DocumentName, `\`, "/"))
+	if name == "." || name == ".." || name == "/" || name == string(filepath.Separator) {
+		name = doc.DocumentID
+	}
+	return os.WriteFile(filepath.Join(dir, doc.DocumentID+"-"+name), contents, 0o600)
+}
+
+func runChangePassword(ctx context.Context, c *cli, args []string) error {
+	fs := c.newFlagSet("change-password")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	password := os.Getenv("NURSYS_NEW_PASSWORD")
+	if password == "" {
+		line, err := bufio.NewReader(c.stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return errors.New("reading new password from stdin: " + err.Error())
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+	if password == "" {
+		return usageError("new password is empty")
+	}
+
+	resp, err := c.client.ChangePassword(ctx, nursys.ChangePasswordSubmitRequestMessage{NewPassword: password})
+	if err != nil {
+		return err
+	}
+	if err := c.print(resp); err != nil {
+		return err
+	}
+	return check(resp.Transaction)
+}
+
+func runPoll(ctx context.Context, c *cli, args []string) error {
+	fs := c.newFlagSet("poll")
+	endpoint := fs.String("endpoint", "", "`endpoint` of the transaction: managenurselist, nurselookup or notificationlookup")
+	interval := fs.Duration("interval", nursys.DefaultPollInterval, "polling interval")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageError("exactly one transaction ID is required")
+	}
+	result, tx, err := pollTransaction(ctx, c.client, *endpoint, fs.Arg(0), *interval)
+	if err != nil {
+		return err
+	}
+	if err := c.untrackPending(fs.Arg(0)); err != nil {
+		return err
+	}
+	if err := c.print(result); err != nil {
+		return err
+	}
+	return check(tx)
+}
+
+// pollTransaction waits for the result of the transaction submitted to endpoint.
+func pollTransaction(ctx context.Context, client nursys.Client, endpoint, txID string, interval time.Duration) (interface{}, nursys.Transaction, error) {
+	switch strings.ToLower(endpoint) {
+	case "managenurselist":
+		r, err := nursys.PollManageNurseListResult(ctx, client, txID, interval)
+		return r, r.Transaction, err
+	case "nurselookup":
+		r, err := nursys.PollNurseLookupResult(ctx, client, txID, interval)
+		return r, r.Transaction, err
+	case "notificationlookup":
+		r, err := nursys.PollNotificationLookupResult(ctx, client, txID, interval)
+		return r, r.Transaction, err
+	}
+	return nil, nursys.Transaction{}, usageError(fmt.Sprintf("unknown endpoint %q", endpoint))
+}
+
+// submitted records a successfully submitted transaction as pending, so that "nursys watch" can collect
+// its result if this process does not.
+func (c *cli) submitted(endpoint string, tx nursys.Transaction, summary string) error {
+	if !tx.TransactionSuccessFlag {
+		return nil
+	}
+	return c.trackPending(endpoint, tx.TransactionID, summary)
+}
+
+// fetchResult retrieves the current result of the transaction submitted to endpoint, and reports whether
+// processing is complete.
+func fetchResult(ctx context.Context, client nursys.Client, endpoint, txID string) (interface{}, nursys.Transaction, bool, error) {
+	switch strings.ToLower(endpoint) {
+	case "managenurselist":
+		r, err := client.GetManageNurseListResult(ctx, txID)
+		return r, r.Transaction, r.ProcessingCompleteFlag, err
+	case "nurselookup":
+		r, err := client.GetNurseLookupResult(ctx, txID)
+		return r, r.Transaction, r.ProcessingCompleteFlag, err
+	case "notificationlookup":
+		r, err := client.GetNotificationLookupResult(ctx, txID)
+		return r, r.Transaction, r.ProcessingCompleteFlag, err
+	}
+	return nil, nursys.Transaction{}, false, fmt.Errorf("unknown endpoint %q", endpoint)
+}
+
+// parseFlags parses the subcommand flags. The flag package reports problems itself, so any
+// error is returned as flag.ErrHelp to exit with a usage status without printing it again.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return flag.ErrHelp
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Environment variables read by the CLI. They take precedence over the config file.
+const (
+	envConfig   = "NURSYS_CONFIG"
+	envURL      = "NURSYS_URL"
+	envUsername = "NURSYS_USERNAME"
+	envPassword = "NURSYS_PASSWORD"
+)
+
+// config holds the connection settings for the Nursys API.
+type config struct {
+	URL      string `json:"url"` // Base URL, or an environment name such as "production" or "test".
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// loadConfig reads the JSON config file at path, if any, and applies environment overrides.
+// An empty path falls back to $NURSYS_CONFIG.
+func loadConfig(path string) (config, error) {
+	var cfg config
+	if path == "" {
+		path = os.Getenv(envConfig)
+	}
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return cfg, err
+		}
+		if err := json.Unmarshal(b, &cfg); err != nil {
+			return cfg, fmt.Errorf("reading config %s: %w", path, err)
+		}
+	}
+	if v := os.Getenv(envURL); v != "" {
+		cfg.URL = v
+	}
+	if v := os.Getenv(envUsername); v != "" {
+		cfg.Username = v
+	}
+	if v := os.Getenv(envPassword); v != "" {
+		cfg.Password = v
+	}
+	if cfg.URL == "" || cfg.Username == "" || cfg.Password == "" {
+		return cfg, errors.New("url, username and password must be set in the config file or via " + envURL + ", " + envUsername + " and " + envPassword)
+	}
+	return cfg, nil
+}
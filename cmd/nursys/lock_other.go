@@ -0,0 +1,35 @@
+//go:build !unix
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// lockTimeout is how long lockFile waits for another process to release the lock.
+const lockTimeout = 30 * time.Second
+
+// lockFile takes an exclusive lock by creating the file at path, waiting for other processes holding it.
+// The returned function releases the lock by removing the file. A lock file left behind by a process that
+// crashed has to be removed by hand.
+func lockFile(path string) (func(), error) {
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o600)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, err
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%s is held by another process; remove it if no nursys command is running", path)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
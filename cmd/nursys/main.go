@@ -0,0 +1,178 @@
+// Command nursys is a command-line client for the Nursys e-Notify API.
+//
+// Usage:
+//
+//	nursys [-config file] [-format json|table] <command> [flags] [arguments]
+//
+// The API URL, username and password are read from the JSON config file ({"url": ..., "username": ...,
+// "password": ...}), which defaults to $NURSYS_CONFIG, and may be overridden with the NURSYS_URL,
+// NURSYS_USERNAME and NURSYS_PASSWORD environment variables.
+//
+// Submitted ManageNurseList, NurseLookup and NotificationLookup transactions are remembered in a state file
+// ($NURSYS_STATE, by default pending.json in the user's nursys config directory) until their result has
+// been retrieved. "nursys watch" collects the results of all pending transactions, so a TransactionId is
+// not lost if the submitting script dies while waiting.
+//
+// The exit status is 0 on success, 1 if a request failed or the API reported TransactionSuccessFlag
+// false, and 2 for usage errors.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+
+	"github.com/connectRN/go-nursys"
+)
+
+// Exit statuses
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+)
+
+// errTransactionFailed is wrapped by errors for transactions whose TransactionSuccessFlag is false.
+var errTransactionFailed = errors.New("transaction failed")
+
+// cli is the environment commands run in.
+type cli struct {
+	client    nursys.Client
+	format    string
+	statePath string
+	stdin     io.Reader
+	stdout    io.Writer
+	stderr    io.Writer
+}
+
+// command is a nursys subcommand.
+type command struct {
+	usage   string // Arguments synopsis
+	summary string // One line description
+	run     func(ctx context.Context, c *cli, args []string) error
+}
+
+var commands = map[string]command{
+	"enroll":          {"-file roster.csv|roster.json [-mapping mapping.json] [-batch n] [-wait]", "Add or update nurses on the nurse list", runEnroll},
+	"remove":          {"-file roster.csv|roster.json [-mapping mapping.json] [-batch n] [-wait]", "Remove nurses from the nurse list", runRemove},
+	"lookup":          {"-file requests.json | -ncsbn id [-jurisdiction st -type rn -number n] [-wait]", "Look up license and discipline information", runLookup},
+	"notifications":   {"-start YYYY-MM-DD [-end YYYY-MM-DD] [-wait]", "Look up license status changes", runNotifications},
+	"documents":       {"[-out dir] documentId...", "Retrieve discipline and notification documents", runDocuments},
+	"change-password": {"", "Change the API password (read from $NURSYS_NEW_PASSWORD or stdin)", runChangePassword},
+	"poll":            {"-endpoint managenurselist|nurselookup|notificationlookup [-interval d] transactionId", "Wait for a transaction result", runPoll},
+	"watch":           {"[-out dir] [-interval d] [-once]", "Collect the results of all pending transactions", runWatch},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// run executes the command line args and returns the exit status.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("nursys", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	configPath := fs.String("config", "", "JSON config `file` with url, username and password (default $"+envConfig+")")
+	format := fs.String("format", "json", "output `format`: json or table")
+	statePath := fs.String("state", defaultStatePath(), "pending transaction state `file`, also set by $"+envState)
+	fs.Usage = func() { usage(fs) }
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		fmt.Fprintf(stderr, "nursys: unknown command %q\n", fs.Arg(0))
+		fs.Usage()
+		return exitUsage
+	}
+	if *format != "json" && *format != "table" {
+		fmt.Fprintf(stderr, "nursys: unknown format %q\n", *format)
+		return exitUsage
+	}
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "nursys: %v\n", err)
+		return exitUsage
+	}
+	baseURL := cfg.URL
+	if env, err := nursys.ParseEnvironment(cfg.URL); err == nil {
+		baseURL = env.BaseURL()
+	}
+	client, err := nursys.NewClient(baseURL, cfg.Username, cfg.Password)
+	if err != nil {
+		fmt.Fprintf(stderr, "nursys: %v\n", err)
+		return exitUsage
+	}
+	c := &cli{
+		client:    client,
+		format:    *format,
+		statePath: *statePath,
+		stdin:     stdin,
+		stdout:    stdout,
+		stderr:    stderr,
+	}
+
+	err = cmd.run(ctx, c, fs.Args()[1:])
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, flag.ErrHelp), errors.As(err, new(usageError)):
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(stderr, "nursys %s: %v\n", fs.Arg(0), err)
+		}
+		return exitUsage
+	default:
+		fmt.Fprintf(stderr, "nursys %s: %v\n", fs.Arg(0), err)
+		return exitFailure
+	}
+}
+
+func usage(fs *flag.FlagSet) {
+	w := fs.Output()
+	fmt.Fprintln(w, "usage: nursys [flags] <command> [command flags] [arguments]")
+	fmt.Fprintln(w, "\nflags:")
+	fs.PrintDefaults()
+	fmt.Fprintln(w, "\ncommands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-16s %s\n  %-16s   %s %s\n", name, commands[name].summary, "", name, commands[name].usage)
+	}
+}
+
+// usageError reports invalid command arguments.
+type usageError string
+
+func (e usageError) Error() string {
+	return string(e)
+}
+
+// newFlagSet returns a flag set for the named subcommand that reports errors instead of exiting.
+func (c *cli) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	return fs
+}
+
+// check returns an error wrapping errTransactionFailed if tx was not successful.
+func check(tx nursys.Transaction) error {
+	if err := tx.Err(); err != nil {
+		return fmt.Errorf("%w: %v", errTransactionFailed, err)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/connectRN/go-nursys"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Run(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/changepassword", req.URL.Path)
+		assert.Equal(t, "acme", req.Header.Get("username"))
+		if req.Header.Get("password") == "good" {
+			rw.Write([]byte(`{"Transaction": {"TransactionId": "tx1", "TransactionSuccessFlag": true}}`))
+		} else {
+			rw.Write([]byte(`{"Transaction": {"TransactionId": "tx2", "TransactionSuccessFlag": false,
+				"TransactionErrors": [{"ErrorId": 210, "ErrorMessage": "Password must be between 8 and 50 characters in length. "}]}}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv(envURL, server.URL)
+	t.Setenv(envUsername, "acme")
+	t.Setenv(envState, filepath.Join(t.TempDir(), "state.json"))
+
+	t.Run("success", func(t *testing.T) {
+		t.Setenv(envPassword, "good")
+		var stdout, stderr bytes.Buffer
+		code := run(context.Background(), []string{"-format", "table", "change-password"}, strings.NewReader("N3wPassw0rd!\n"), &stdout, &stderr)
+		assert.Equal(t, exitOK, code, stderr.String())
+		assert.Contains(t, stdout.String(), "tx1")
+	})
+
+	t.Run("transaction failed", func(t *testing.T) {
+		t.Setenv(envPassword, "bad")
+		var stdout, stderr bytes.Buffer
+		code := run(context.Background(), []string{"change-password"}, strings.NewReader("short\n"), &stdout, &stderr)
+		assert.Equal(t, exitFailure, code)
+		assert.Contains(t, stdout.String(), `"TransactionId": "tx2"`)
+		assert.Equal(t, "nursys change-password: transaction failed: nursys: transaction tx2 failed: 210 Password must be between 8 and 50 characters in length.\n", stderr.String())
+	})
+
+	t.Run("usage", func(t *testing.T) {
+		t.Setenv(envPassword, "good")
+		var stdout, stderr bytes.Buffer
+		assert.Equal(t, exitUsage, run(context.Background(), []string{"frobnicate"}, nil, &stdout, &stderr))
+		assert.Equal(t, exitUsage, run(context.Background(), []string{"lookup"}, nil, &stdout, &stderr))
+		assert.Equal(t, exitUsage, run(context.Background(), []string{"poll", "-endpoint", "nope", "tx1"}, nil, &stdout, &stderr))
+	})
+}
+
+func Test_Watch(t *testing.T) {
+	dir := t.TempDir()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodPost:
+			assert.Equal(t, "/nurselookup", req.URL.Path)
+			rw.Write([]byte(`{"Transaction": {"TransactionId": "tx1", "TransactionSuccessFlag": true}}`))
+		case http.MethodGet:
+			assert.Equal(t, "/nurselookup?transactionId=tx1", req.URL.String())
+			rw.Write([]byte(`{"ProcessingCompleteFlag": true, "Transaction": {"TransactionId": "tx1", "TransactionSuccessFlag": true}}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv(envURL, server.URL)
+	t.Setenv(envUsername, "acme")
+	t.Setenv(envPassword, "1234!")
+	t.Setenv(envState, filepath.Join(dir, "state.json"))
+
+	// The script submitting the lookup dies before collecting the result.
+	var stdout, stderr bytes.Buffer
+	require.Equal(t, exitOK, run(context.Background(), []string{"lookup", "-ncsbn", "12345678"}, nil, &stdout, &stderr), stderr.String())
+	s, err := loadState(filepath.Join(dir, "state.json"))
+	require.NoError(t, err)
+	require.Len(t, s.Pending, 1)
+	assert.Equal(t, "tx1", s.Pending[0].TransactionID)
+	assert.Equal(t, "nurselookup", s.Pending[0].Endpoint)
+
+	stdout.Reset()
+	require.Equal(t, exitOK, run(context.Background(), []string{"watch", "-out", dir, "-once"}, nil, &stdout, &stderr), stderr.String())
+	assert.Contains(t, stdout.String(), `"Summary": "lookup 1 nurses"`)
+	assert.FileExists(t, filepath.Join(dir, "tx1.json"))
+	s, err = loadState(filepath.Join(dir, "state.json"))
+	require.NoError(t, err)
+	assert.Empty(t, s.Pending)
+}
+
+func Test_WatchUnavailableResult(t *testing.T) {
+	dir := t.TempDir()
+	submitted := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == http.MethodPost:
+			submitted++
+			fmt.Fprintf(rw, `{"Transaction": {"TransactionId": "tx%d", "TransactionSuccessFlag": true}}`, submitted)
+		case req.URL.Query().Get("transactionId") == "tx1":
+			rw.WriteHeader(http.StatusBadRequest) // Expired transaction ID
+		default:
+			rw.Write([]byte(`{"ProcessingCompleteFlag": true, "Transaction": {"TransactionSuccessFlag": true}}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv(envURL, server.URL)
+	t.Setenv(envUsername, "acme")
+	t.Setenv(envPassword, "1234!")
+	t.Setenv(envState, filepath.Join(dir, "state.json"))
+
+	var stdout, stderr bytes.Buffer
+	for range 3 {
+		require.Equal(t, exitOK, run(context.Background(), []string{"lookup", "-ncsbn", "12345678"}, nil, &stdout, &stderr), stderr.String())
+	}
+
+	stdout.Reset()
+	assert.Equal(t, exitFailure, run(context.Background(), []string{"-format", "table", "watch", "-out", dir}, nil, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "nursys watch: tx1: ")
+	assert.Equal(t, 1, strings.Count(stdout.String(), "TRANSACTION"), stdout.String())
+	assert.Contains(t, stdout.String(), "tx2")
+	assert.Contains(t, stdout.String(), "tx3")
+	assert.FileExists(t, filepath.Join(dir, "tx3.json"))
+
+	// The unavailable transaction is still pending.
+	s, err := loadState(filepath.Join(dir, "state.json"))
+	require.NoError(t, err)
+	require.Len(t, s.Pending, 1)
+	assert.Equal(t, "tx1", s.Pending[0].TransactionID)
+}
+
+func Test_SaveDocumentPaths(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+	require.NoError(t, os.Mkdir(dir, 0o700))
+
+	require.NoError(t, saveDocument(dir, nursys.RetrieveDocumentResponse{DocumentID: "doc-1", DocumentName: `..\..\order.pdf`, DocumentContents: "x"}))
+	require.NoError(t, saveDocument(dir, nursys.RetrieveDocumentResponse{DocumentID: "doc-2", DocumentName: "../../order.pdf", DocumentContents: "x"}))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	assert.Equal(t, []string{"doc-1-order.pdf", "doc-2-order.pdf"}, names)
+
+	for _, id := range []string{"../../x", `..\x`, "..", ""} {
+		assert.Error(t, saveDocument(dir, nursys.RetrieveDocumentResponse{DocumentID: id, DocumentName: "order.pdf"}), id)
+	}
+	parent, err := os.ReadDir(filepath.Dir(dir))
+	require.NoError(t, err)
+	assert.Len(t, parent, 1)
+}
+
+func Test_TrackPendingConcurrently(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c := &cli{statePath: path}
+			assert.NoError(t, c.trackPending("nurselookup", fmt.Sprintf("tx%d", i), "lookup"))
+		}(i)
+	}
+	wg.Wait()
+
+	s, err := loadState(path)
+	require.NoError(t, err)
+	assert.Len(t, s.Pending, 100)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/connectRN/go-nursys"
+)
+
+// print writes v to stdout in the selected format. Types without a table layout are always written as JSON.
+func (c *cli) print(v interface{}) error {
+	if c.format == "table" {
+		tw := tabwriter.NewWriter(c.stdout, 0, 4, 2, ' ', 0)
+		if printTable(tw, v) {
+			return tw.Flush()
+		}
+	}
+	enc := json.NewEncoder(c.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// printTable writes v as a table and reports whether v has a table layout.
+func printTable(w *tabwriter.Writer, v interface{}) bool {
+	switch v := v.(type) {
+	case nursys.ManageNurseListSubmitResponseMessage:
+		printTransaction(w, v.Transaction)
+	case nursys.NotificationLookupSubmitResponseMessage:
+		printTransaction(w, v.Transaction)
+	case nursys.ChangePasswordSubmitResponseMessage:
+		printTransaction(w, v.Transaction)
+	case nursys.ManageNurseListRetrieveResponseMessage:
+		printTransaction(w, v.Transaction)
+		fmt.Fprintln(w, "\nRECORD\tACTION\tJURISDICTION\tTYPE\tLICENSE\tNCSBN ID\tSUCCESS\tERRORS")
+		for _, r := range v.ManageNurseListResponses {
+			req := r.ManageNurseListRequest
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%t\t%s\n", req.RecordID, req.SubmissionActionCode, req.JurisdictionAbbreviation,
+				req.LicenseType, req.LicenseNumber, req.NcsbnID, r.SuccessFlag, errorMessages(r.Errors))
+		}
+	case nursys.NurseLookupRetrieveResponseMessage:
+		printTransaction(w, v.Transaction)
+		fmt.Fprintln(w, "\nNAME\tNCSBN ID\tJURISDICTION\tTYPE\tLICENSE\tACTIVE\tSTATUS\tEXPIRES\tDISCIPLINES")
+		for _, r := range v.NurseLookupResponses {
+			name := r.LastName + ", " + r.FirstName
+			if !r.SuccessFlag {
+				fmt.Fprintf(w, "%s\t%s\t\t\t\t\t%s\t\t\n", name, r.NcsbnID, errorMessages(r.Errors))
+			}
+			for _, l := range r.NurseLookupLicenses {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\n", name, r.NcsbnID, l.JurisdictionAbbreviation, l.LicenseType,
+					l.LicenseNumber, l.Active, l.LicenseStatus, l.LicenseExpirationDate, len(l.NurseLookupDisciplines))
+			}
+		}
+	case nursys.NotificationLookupRetrieveResponseMessage:
+		printTransaction(w, v.Transaction)
+		fmt.Fprintln(w, "\nDATE\tNCSBN ID\tNAME\tJURISDICTION\tTYPE\tLICENSE\tLICENSE CHANGE\tDISCIPLINE CHANGE")
+		for _, r := range v.NotificationLookupResponses {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", formatDate(r.NotificationDate), r.NcsbnID, r.LastName+", "+r.FirstName,
+				r.JurisdictionAbbreviation, r.LicenseType, r.LicenseNumber, r.LicenseStatusChange, r.DisciplineStatusChange)
+		}
+	case nursys.RetrieveDocumentsRetrieveResponseMessage:
+		printTransaction(w, v.Transaction)
+		fmt.Fprintln(w, "\nDOCUMENT ID\tNAME\tSUCCESS")
+		for _, d := range v.Documents {
+			fmt.Fprintf(w, "%s\t%s\t%t\n", d.DocumentID, d.DocumentName, d.SuccessFlag)
+		}
+	case watchResults:
+		fmt.Fprintln(w, "TRANSACTION\tENDPOINT\tSUMMARY\tSUCCESS\tFILE")
+		for _, r := range v {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\n", r.TransactionID, r.Endpoint, r.Summary, r.TransactionSuccessFlag, r.File)
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+func printTransaction(w *tabwriter.Writer, tx nursys.Transaction) {
+	fmt.Fprintln(w, "TRANSACTION\tDATE\tSUCCESS\tCOMMENT\tERRORS")
+	fmt.Fprintf(w, "%s\t%s\t%t\t%s\t%s\n", tx.TransactionID, time.Time(tx.TransactionDate).Format(time.RFC3339),
+		tx.TransactionSuccessFlag, strings.TrimSpace(tx.TransactionComment), errorMessages(tx.TransactionErrors))
+}
+
+func errorMessages(errs []nursys.TransactionError) string {
+	messages := make([]string, len(errs))
+	for i, e := range errs {
+		messages[i] = fmt.Sprintf("%d %s", e.ErrorID, strings.TrimSpace(e.ErrorMessage))
+	}
+	return strings.Join(messages, "; ")
+}
+
+func formatDate(t nursys.Time) string {
+	if time.Time(t).IsZero() {
+		return ""
+	}
+	return time.Time(t).Format(time.DateOnly)
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// envState names the environment variable holding the path of the pending transaction state file.
+const envState = "NURSYS_STATE"
+
+// pendingTransaction is a submitted transaction whose result has not been retrieved yet.
+type pendingTransaction struct {
+	TransactionID string    `json:"TransactionId"`
+	Endpoint      string    `json:"Endpoint"` // managenurselist, nurselookup or notificationlookup
+	Summary       string    `json:"Summary"`  // Human readable description of the request
+	SubmittedAt   time.Time `json:"SubmittedAt"`
+}
+
+// state is the content of the state file, which remembers submitted transactions so that their results
+// can be collected by "nursys watch" even if the submitting process died.
+type state struct {
+	Pending []pendingTransaction `json:"Pending"`
+}
+
+// defaultStatePath returns $NURSYS_STATE, or pending.json in the user's nursys config directory.
+func defaultStatePath() string {
+	if path := os.Getenv(envState); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "nursys-pending.json"
+	}
+	return filepath.Join(dir, "nursys", "pending.json")
+}
+
+func loadState(path string) (state, error) {
+	var s state
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	} else if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(b, &s); err != nil {
+		return s, fmt.Errorf("reading state %s: %w", path, err)
+	}
+	return s, nil
+}
+
+func saveState(path string, s state) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	// A temporary file of its own, so that concurrent writers never rename each other's partial files.
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(b)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+// updateState applies update to the state file and saves it if update reports a change. The file is locked
+// from load to save, so that concurrent nursys processes don't lose each other's changes.
+func updateState(path string, update func(*state) bool) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	unlock, err := lockFile(path + ".lock")
+	if err != nil {
+		return fmt.Errorf("locking state %s: %w", path, err)
+	}
+	defer unlock()
+	s, err := loadState(path)
+	if err != nil {
+		return err
+	}
+	if !update(&s) {
+		return nil
+	}
+	return saveState(path, s)
+}
+
+// trackPending records a submitted transaction in the state file.
+func (c *cli) trackPending(endpoint, txID, summary string) error {
+	return updateState(c.statePath, func(s *state) bool {
+		s.Pending = append(s.Pending, pendingTransaction{
+			TransactionID: txID,
+			Endpoint:      endpoint,
+			Summary:       summary,
+			SubmittedAt:   time.Now(),
+		})
+		return true
+	})
+}
+
+// untrackPending removes a transaction whose result has been retrieved from the state file.
+func (c *cli) untrackPending(txID string) error {
+	return updateState(c.statePath, func(s *state) bool {
+		pending := s.Pending[:0]
+		for _, p := range s.Pending {
+			if p.TransactionID != txID {
+				pending = append(pending, p)
+			}
+		}
+		if len(pending) == len(s.Pending) {
+			return false
+		}
+		s.Pending = pending
+		return true
+	})
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/connectRN/go-nursys"
+)
+
+// watchResult reports a pending transaction whose result was collected by "nursys watch".
+type watchResult struct {
+	TransactionID          string `json:"TransactionId"`
+	Endpoint               string `json:"Endpoint"`
+	Summary                string `json:"Summary"`
+	File                   string `json:"File"`
+	TransactionSuccessFlag bool   `json:"TransactionSuccessFlag"`
+}
+
+// watchResults are the results collected by "nursys watch", printed as one table.
+type watchResults []watchResult
+
+func runWatch(ctx context.Context, c *cli, args []string) error {
+	fs := c.newFlagSet("watch")
+	out := fs.String("out", ".", "`directory` to write results to, as <TransactionId>.json")
+	interval := fs.Duration("interval", nursys.DefaultPollInterval, "polling interval")
+	once := fs.Bool("once", false, "check each pending transaction once instead of waiting for all of them")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(*out, 0o700); err != nil {
+		return err
+	}
+
+	// Results are streamed as JSON, but collected into a single table.
+	var table watchResults
+	report := func(r watchResult) error {
+		if c.format == "table" {
+			table = append(table, r)
+			return nil
+		}
+		return c.print(r)
+	}
+	printTable := func() error {
+		if len(table) == 0 {
+			return nil
+		}
+		return c.print(table)
+	}
+
+	failed, unavailable := 0, 0
+	skipped := map[string]bool{} // Transactions whose result could not be fetched, not polled again by this run.
+	for {
+		s, err := loadState(c.statePath)
+		if err != nil {
+			return err
+		}
+		remaining := 0
+		for _, p := range s.Pending {
+			if skipped[p.TransactionID] {
+				continue
+			}
+			result, tx, done, err := fetchResult(ctx, c.client, p.Endpoint, p.TransactionID)
+			if ctx.Err() != nil {
+				printTable()
+				return ctx.Err()
+			}
+			if err != nil {
+				// Keep the transaction pending for a later run, and carry on with the others.
+				fmt.Fprintf(c.stderr, "nursys watch: %s: %v\n", p.TransactionID, err)
+				skipped[p.TransactionID] = true
+				unavailable++
+				continue
+			}
+			if !done {
+				remaining++
+				continue
+			}
+			path := filepath.Join(*out, p.TransactionID+".json")
+			if err := writeJSON(path, result); err != nil {
+				return err
+			}
+			if err := c.untrackPending(p.TransactionID); err != nil {
+				return err
+			}
+			if err := report(watchResult{p.TransactionID, p.Endpoint, p.Summary, path, tx.TransactionSuccessFlag}); err != nil {
+				return err
+			}
+			if err := tx.Err(); err != nil {
+				fmt.Fprintf(c.stderr, "nursys watch: %v\n", err)
+				failed++
+			}
+		}
+		if remaining == 0 || *once {
+			break
+		}
+
+		timer := time.NewTimer(*interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			printTable()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if err := printTable(); err != nil {
+		return err
+	}
+	if unavailable > 0 {
+		return fmt.Errorf("results of %d transactions could not be retrieved; they are still pending", unavailable)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%w: %d transactions", errTransactionFailed, failed)
+	}
+	return nil
+}
+
+func writeJSON(path string, v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o600)
+}
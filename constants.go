@@ -4,12 +4,12 @@ package nursys
 
 // A.2 License types
 const (
-	LicenseTypeRN   = "RN"   // Registered Nurse
-	LicenseTypePN   = "PN"   // Practical Nurse (Vocational Nurse)
-	LicenseTypeCNM  = "CNM"  // Certified Nurse Midwife
-	LicenseTypeCRNA = "CRNA" // Certified Registered Nurse Anesthetist
-	LicenseTypeCNS  = "CNS"  // Clinical Nurse Specialist
-	LicenseTypeCNP  = "CNP"  // Certified Nurse Practitioner
+	LicenseTypeRN   LicenseType = "RN"   // Registered Nurse
+	LicenseTypePN   LicenseType = "PN"   // Practical Nurse (Vocational Nurse)
+	LicenseTypeCNM  LicenseType = "CNM"  // Certified Nurse Midwife
+	LicenseTypeCRNA LicenseType = "CRNA" // Certified Registered Nurse Anesthetist
+	LicenseTypeCNS  LicenseType = "CNS"  // Clinical Nurse Specialist
+	LicenseTypeCNP  LicenseType = "CNP"  // Certified Nurse Practitioner
 )
 
 // A.7 Submission Action Codes
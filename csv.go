@@ -0,0 +1,304 @@
+package nursys
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSVColumnMapping maps ManageNurseListRequest field names, as spelled in the API (e.g. "LastFourSSN",
+// "NcsbnId"), to the header of the CSV column holding that field. Fields without a mapping, or whose
+// column is absent from the file, are left empty, so every row fails if such a field is required by the API.
+type CSVColumnMapping map[string]string
+
+// DefaultCSVColumnMapping returns a mapping in which every column header is the API field name.
+func DefaultCSVColumnMapping() CSVColumnMapping {
+	mapping := CSVColumnMapping{}
+	t := reflect.TypeOf(ManageNurseListRequest{})
+	for i := 0; i < t.NumField(); i++ {
+		name := jsonFieldName(t.Field(i))
+		mapping[name] = name
+	}
+	return mapping
+}
+
+// CSVRowError describes a problem with a single CSV row. Reading can continue after a CSVRowError.
+type CSVRowError struct {
+	Line   int    // Line number in the file, starting at 1.
+	Column string // Header of the offending column, if the error concerns a single column.
+	Err    error
+}
+
+// Error implements the error interface.
+func (e *CSVRowError) Error() string {
+	if e.Column != "" {
+		return fmt.Sprintf("line %d: column %q: %v", e.Line, e.Column, e.Err)
+	}
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *CSVRowError) Unwrap() error {
+	return e.Err
+}
+
+// CSVReader reads ManageNurseListRequest records from a CSV file with a header row.
+//
+// Values are normalized as they are read: codes are upper-cased, jurisdictions and license types must be valid, State must be a two-letter
+// abbreviation, Zip and LastFourSSN get back leading zeros lost by spreadsheets, BirthYear must be a
+// four digit year, the notification and reminder flags accept yes/no/true/false/1/0 and become "Y" or
+// "N", and a missing SubmissionActionCode defaults to ActionCodeAdd.
+type CSVReader struct {
+	r       *csv.Reader
+	mapping CSVColumnMapping
+	columns []csvColumn // Mapped columns present in the file, in ManageNurseListRequest field order. Nil until the header is read.
+}
+
+type csvColumn struct {
+	field  string // API field name
+	header string // Column header from the mapping
+	index  int
+}
+
+// NewCSVReader returns a CSVReader reading from r using the given column mapping.
+// A nil mapping means DefaultCSVColumnMapping.
+func NewCSVReader(r io.Reader, mapping CSVColumnMapping) *CSVReader {
+	if mapping == nil {
+		mapping = DefaultCSVColumnMapping()
+	}
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	return &CSVReader{r: cr, mapping: mapping}
+}
+
+// Read returns the next record. Problems with the row's contents are returned as a *CSVRowError, and
+// Read may be called again to continue with the next row. At the end of the input Read returns io.EOF.
+func (r *CSVReader) Read() (ManageNurseListRequest, error) {
+	if r.columns == nil {
+		if err := r.readHeader(); err != nil {
+			return ManageNurseListRequest{}, err
+		}
+	}
+	record, err := r.r.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return ManageNurseListRequest{}, &CSVRowError{Line: parseErr.Line, Err: parseErr.Err}
+		}
+		return ManageNurseListRequest{}, err
+	}
+	line, _ := r.r.FieldPos(0)
+
+	var req ManageNurseListRequest
+	v := reflect.ValueOf(&req).Elem()
+	for _, col := range r.columns {
+		if col.index >= len(record) {
+			continue
+		}
+		value, err := normalizeCSVValue(col.field, strings.TrimSpace(record[col.index]))
+		if err != nil {
+			return ManageNurseListRequest{}, &CSVRowError{Line: line, Column: col.header, Err: err}
+		}
+		f, _ := fieldByJSONName(v, col.field)
+		f.SetString(value)
+	}
+	if req.SubmissionActionCode == "" {
+		req.SubmissionActionCode = ActionCodeAdd
+	}
+	if err := checkRequired(req); err != nil {
+		return ManageNurseListRequest{}, &CSVRowError{Line: line, Err: err}
+	}
+	return req, nil
+}
+
+// ReadAll reads the remaining records. Rows with errors are skipped and reported in rowErrs; err is set
+// only if the file as a whole could not be read.
+func (r *CSVReader) ReadAll() (requests []ManageNurseListRequest, rowErrs []*CSVRowError, err error) {
+	for {
+		req, err := r.Read()
+		var rowErr *CSVRowError
+		switch {
+		case err == io.EOF:
+			return requests, rowErrs, nil
+		case errors.As(err, &rowErr):
+			rowErrs = append(rowErrs, rowErr)
+		case err != nil:
+			return requests, rowErrs, err
+		default:
+			requests = append(requests, req)
+		}
+	}
+}
+
+func (r *CSVReader) readHeader() error {
+	header, err := r.r.Read()
+	if err != nil {
+		if err == io.EOF {
+			return errors.New("nursys: CSV file has no header row")
+		}
+		return err
+	}
+	index := make(map[string]int, len(header))
+	for i, h := range header {
+		index[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	for _, field := range sortedKeys(r.mapping) {
+		if _, ok := fieldByJSONName(reflect.ValueOf(ManageNurseListRequest{}), field); !ok {
+			return fmt.Errorf("nursys: CSV mapping refers to unknown field %q", field)
+		}
+	}
+	r.columns = []csvColumn{}
+	t := reflect.TypeOf(ManageNurseListRequest{})
+	for i := 0; i < t.NumField(); i++ {
+		field := jsonFieldName(t.Field(i))
+		column, ok := r.mappedColumn(field)
+		if !ok {
+			continue
+		}
+		if i, ok := index[strings.ToLower(column)]; ok {
+			r.columns = append(r.columns, csvColumn{field: field, header: column, index: i})
+		}
+	}
+	return nil
+}
+
+// mappedColumn returns the column header mapped to an API field name, matched ignoring case like
+// fieldByJSONName.
+func (r *CSVReader) mappedColumn(field string) (string, bool) {
+	for _, f := range sortedKeys(r.mapping) {
+		if strings.EqualFold(f, field) {
+			return r.mapping[f], true
+		}
+	}
+	return "", false
+}
+
+// checkRequired verifies that every field required by the API is present. A required field without a
+// column in the file is reported as missing on every row.
+func checkRequired(req ManageNurseListRequest) error {
+	v := reflect.ValueOf(req)
+	for _, field := range []string{"Address1", "City", "State", "Zip", "LastFourSSN", "BirthYear", "NotificationsEnabled", "RemindersEnabled"} {
+		if f, _ := fieldByJSONName(v, field); f.String() == "" {
+			return fmt.Errorf("missing required field %s", field)
+		}
+	}
+	return nil
+}
+
+func normalizeCSVValue(field, value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	switch field {
+	case "SubmissionActionCode", "HospitalPracticeSetting":
+		return strings.ToUpper(value), nil
+	case "LicenseType":
+		lt, err := ParseLicenseType(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid license type %q", value)
+		}
+		return string(lt), nil
+	case "JurisdictionAbbreviation":
+		j, err := ParseJurisdiction(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid jurisdiction %q", value)
+		}
+		return string(j), nil
+	case "State":
+		value = strings.ToUpper(value)
+		if len(value) != 2 || !isLetters(value) {
+			return "", fmt.Errorf("invalid state %q", value)
+		}
+		return value, nil
+	case "Zip":
+		digits := strings.ReplaceAll(value, "-", "")
+		if !isDigits(digits) {
+			return "", fmt.Errorf("invalid zip code %q", value)
+		}
+		switch {
+		case len(digits) == 9:
+			return digits[:5] + "-" + digits[5:], nil
+		case len(digits) >= 3 && len(digits) <= 5:
+			return leftPadZeros(digits, 5), nil
+		}
+		return "", fmt.Errorf("invalid zip code %q", value)
+	case "LastFourSSN":
+		if !isDigits(value) || len(value) > 4 {
+			return "", fmt.Errorf("invalid last four SSN digits")
+		}
+		return leftPadZeros(value, 4), nil
+	case "BirthYear":
+		year, err := strconv.Atoi(value)
+		if err != nil || len(value) != 4 || year < 1900 || year > time.Now().Year() {
+			return "", fmt.Errorf("invalid birth year %q", value)
+		}
+		return value, nil
+	case "NotificationsEnabled", "RemindersEnabled":
+		switch strings.ToLower(value) {
+		case "y", "yes", "true", "1":
+			return "Y", nil
+		case "n", "no", "false", "0":
+			return "N", nil
+		}
+		return "", fmt.Errorf("invalid flag %q", value)
+	}
+	return value, nil
+}
+
+// WriteManageNurseListResultsCSV writes the outcome of each nurse in a ManageNurseList result to w as CSV,
+// one row per nurse, for sharing with the people who supplied the roster. Only identifying fields of
+// the request are written; SSN digits, birth year and addresses are left out.
+func WriteManageNurseListResultsCSV(w io.Writer, responses []MangeNurseListResponse) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"RecordId", "SubmissionActionCode", "JurisdictionAbbreviation", "LicenseType", "LicenseNumber", "NcsbnId", "SuccessFlag", "Errors"})
+	for _, resp := range responses {
+		req := resp.ManageNurseListRequest
+		messages := make([]string, len(resp.Errors))
+		for i, e := range resp.Errors {
+			messages[i] = strings.TrimSpace(e.ErrorMessage)
+		}
+		cw.Write([]string{
+			req.RecordID,
+			req.SubmissionActionCode,
+			string(req.JurisdictionAbbreviation),
+			string(req.LicenseType),
+			req.LicenseNumber,
+			req.NcsbnID,
+			strconv.FormatBool(resp.SuccessFlag),
+			strings.Join(messages, "; "),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func isLetters(s string) bool {
+	for _, c := range s {
+		if (c < 'A' || c > 'Z') && (c < 'a' || c > 'z') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func leftPadZeros(s string, width int) string {
+	if len(s) >= width {
+		return s
+	}
+	return strings.Repeat("0", width-len(s)) + s
+}
@@ -0,0 +1,96 @@
+package nursys_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/connectRN/go-nursys"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CSVReader(t *testing.T) {
+	assert := assert.New(t)
+
+	const input = `Employee,State Board,License #,Type,Street,Town,ST,Postal,SSN4,Born,Notify,Remind
+emp-1,ny,123456,rn,1 Main St,Albany,ny,7030,12,1980,yes,0
+emp-2,NY,234567,RN,2 Main St,Albany,NY,12207-1234,1234,80,Y,N
+emp-3,NY,345678,RN,,Albany,NY,12207,1234,1980,Y,N
+"emp-4,NY,456789,RN,4 Main St,Albany,NY,122071234,1234,1985,true,false
+`
+	mapping := nursys.CSVColumnMapping{
+		"RecordId":                 "Employee",
+		"JurisdictionAbbreviation": "State Board",
+		"LicenseNumber":            "License #",
+		"LicenseType":              "Type",
+		"Address1":                 "Street",
+		"City":                     "Town",
+		"State":                    "ST",
+		"Zip":                      "Postal",
+		"LastFourSSN":              "SSN4",
+		"BirthYear":                "Born",
+		"NotificationsEnabled":     "Notify",
+		"RemindersEnabled":         "Remind",
+	}
+
+	requests, rowErrs, err := nursys.NewCSVReader(strings.NewReader(input), mapping).ReadAll()
+	require.NoError(t, err)
+	assert.Equal([]nursys.ManageNurseListRequest{{
+		SubmissionActionCode:     nursys.ActionCodeAdd,
+		RecordID:                 "emp-1",
+		JurisdictionAbbreviation: "NY",
+		LicenseNumber:            "123456",
+		LicenseType:              nursys.LicenseTypeRN,
+		Address1:                 "1 Main St",
+		City:                     "Albany",
+		State:                    "NY",
+		Zip:                      "07030",
+		LastFourSSN:              "0012",
+		BirthYear:                "1980",
+		NotificationsEnabled:     "Y",
+		RemindersEnabled:         "N",
+	}}, requests)
+
+	require.Len(t, rowErrs, 3)
+	assert.Equal(`line 3: column "Born": invalid birth year "80"`, rowErrs[0].Error())
+	assert.Equal(`line 4: missing required field Address1`, rowErrs[1].Error())
+	assert.Equal(5, rowErrs[2].Line) // Unterminated quote
+}
+
+func Test_CSVReader_ErrorOrder(t *testing.T) {
+	// Every cell is invalid; the first field in ManageNurseListRequest order is reported, every time.
+	const input = "BirthYear,Zip,State,LicenseType,JurisdictionAbbreviation\n" +
+		"80,x,New York,XX,ZZ\n"
+	for range 20 {
+		_, err := nursys.NewCSVReader(strings.NewReader(input), nil).Read()
+		assert.EqualError(t, err, `line 2: column "JurisdictionAbbreviation": invalid jurisdiction "ZZ"`)
+	}
+}
+
+func Test_CSVReader_RequiredWithoutColumn(t *testing.T) {
+	const input = "JurisdictionAbbreviation,LicenseType,LicenseNumber,Address1,City,State,Zip,LastFourSSN,BirthYear,NotificationsEnabled\n" +
+		"NY,RN,1,1 Main St,Albany,NY,12207,1234,1980,Y\n"
+	_, err := nursys.NewCSVReader(strings.NewReader(input), nil).Read()
+	assert.EqualError(t, err, "line 2: missing required field RemindersEnabled")
+}
+
+func Test_WriteManageNurseListResultsCSV(t *testing.T) {
+	req := testNurse("123456")
+	req.RecordID = "emp-1"
+	req.SubmissionActionCode = nursys.ActionCodeAdd
+
+	var buf bytes.Buffer
+	err := nursys.WriteManageNurseListResultsCSV(&buf, []nursys.MangeNurseListResponse{
+		{SuccessFlag: true, ManageNurseListRequest: req},
+		{SuccessFlag: false, ManageNurseListRequest: req, Errors: []nursys.TransactionError{
+			{ErrorID: 1, ErrorMessage: "Invalid zip. "},
+			{ErrorID: 2, ErrorMessage: "Invalid birth year. "},
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "RecordId,SubmissionActionCode,JurisdictionAbbreviation,LicenseType,LicenseNumber,NcsbnId,SuccessFlag,Errors\n"+
+		"emp-1,A,NY,RN,123456,,true,\n"+
+		"emp-1,A,NY,RN,123456,,false,Invalid zip.; Invalid birth year.\n", buf.String())
+}
@@ -0,0 +1,115 @@
+package nursys
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Fingerprint returns a stable identifier for the status change described by this notification.
+// Two responses describing the same change (for example, returned by overlapping NotificationLookup
+// date ranges) have the same fingerprint. Client-provided and descriptive fields such as RecordID and
+// the licensee name are not part of the fingerprint.
+func (r NotificationLookupResponse) Fingerprint() string {
+	parts := []string{
+		r.NcsbnID,
+		string(r.JurisdictionAbbreviation),
+		r.LicenseNumber,
+		string(r.LicenseType),
+		time.Time(r.NotificationDate).UTC().Format(time.RFC3339Nano),
+		r.LicenseStatusChange,
+		r.DisciplineStatusChange,
+		r.DisciplineStatusChangeOther,
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x1f"))) // Unit separator can't appear in the data
+	return hex.EncodeToString(sum[:])
+}
+
+// SeenStore remembers which notification fingerprints have already been processed.
+// Implementations must be safe for concurrent use.
+type SeenStore interface {
+	// Seen reports whether the fingerprint was recorded, without recording it.
+	Seen(ctx context.Context, fingerprint string) (bool, error)
+	// MarkSeen records the fingerprint and reports whether it was already present.
+	MarkSeen(ctx context.Context, fingerprint string) (seen bool, err error)
+}
+
+// MemorySeenStore is an in-memory SeenStore. It is useful for tests and for deduplicating
+// within a single process; use a persistent implementation to deduplicate across restarts.
+type MemorySeenStore struct {
+	mu   sync.Mutex
+	seen map[string]struct{}
+}
+
+// NewMemorySeenStore creates an empty MemorySeenStore.
+func NewMemorySeenStore() *MemorySeenStore {
+	return &MemorySeenStore{seen: make(map[string]struct{})}
+}
+
+// Seen implements SeenStore.
+func (s *MemorySeenStore) Seen(_ context.Context, fingerprint string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.seen[fingerprint]
+	return ok, nil
+}
+
+// MarkSeen implements SeenStore.
+func (s *MemorySeenStore) MarkSeen(_ context.Context, fingerprint string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.seen[fingerprint]; ok {
+		return true, nil
+	}
+	s.seen[fingerprint] = struct{}{}
+	return false, nil
+}
+
+// DedupeNotifications returns the notifications that have not been seen before, in their original order,
+// and marks them as seen in store. Duplicates within responses are also removed.
+//
+// The notifications are marked as seen before they are processed, so a notification whose processing fails
+// is not returned again. Use HandleNotifications to mark notifications only once they were handled.
+//
+// If store returns an error, the notifications filtered so far are returned along with the error.
+func DedupeNotifications(ctx context.Context, store SeenStore, responses []NotificationLookupResponse) ([]NotificationLookupResponse, error) {
+	fresh := make([]NotificationLookupResponse, 0, len(responses))
+	for _, r := range responses {
+		seen, err := store.MarkSeen(ctx, r.Fingerprint())
+		if err != nil {
+			return fresh, err
+		}
+		if !seen {
+			fresh = append(fresh, r)
+		}
+	}
+	return fresh, nil
+}
+
+// HandleNotifications calls handler, in order, for each notification that has not been seen before, and
+// marks it as seen in store once handler succeeds. Duplicates within responses are handled once.
+//
+// It stops at the first error of handler or store and returns it. The failed notification is not marked
+// as seen, so it is handled again by a later call with an overlapping lookup.
+func HandleNotifications(ctx context.Context, store SeenStore, responses []NotificationLookupResponse, handler func(context.Context, NotificationLookupResponse) error) error {
+	for _, r := range responses {
+		fingerprint := r.Fingerprint()
+		seen, err := store.Seen(ctx, fingerprint)
+		if err != nil {
+			return err
+		}
+		if seen {
+			continue
+		}
+		if err := handler(ctx, r); err != nil {
+			return err
+		}
+		if _, err := store.MarkSeen(ctx, fingerprint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
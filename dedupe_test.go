@@ -0,0 +1,81 @@
+package nursys_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/connectRN/go-nursys"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DedupeNotifications(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+
+	first := nursys.NotificationLookupResponse{
+		NcsbnID:                  "12345678",
+		JurisdictionAbbreviation: "NY",
+		LicenseNumber:            "123456",
+		LicenseType:              nursys.LicenseTypeRN,
+		NotificationDate:         nursys.Time(time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)),
+		LicenseStatusChange:      "Expired",
+		RecordID:                 "emp-1",
+	}
+	second := first
+	second.LicenseStatusChange = "Active"
+
+	// Same change reported again with a different RecordID and zone.
+	repeat := first
+	repeat.RecordID = "emp-1-again"
+	repeat.NotificationDate = nursys.Time(time.Date(2024, 1, 3, 19, 0, 0, 0, time.FixedZone("", -5*60*60)))
+	assert.Equal(first.Fingerprint(), repeat.Fingerprint())
+	assert.NotEqual(first.Fingerprint(), second.Fingerprint())
+
+	store := nursys.NewMemorySeenStore()
+
+	fresh, err := nursys.DedupeNotifications(ctx, store, []nursys.NotificationLookupResponse{first, repeat})
+	require.NoError(t, err)
+	assert.Equal([]nursys.NotificationLookupResponse{first}, fresh)
+
+	// Overlapping lookup returns the old change plus a new one.
+	fresh, err = nursys.DedupeNotifications(ctx, store, []nursys.NotificationLookupResponse{repeat, second})
+	require.NoError(t, err)
+	assert.Equal([]nursys.NotificationLookupResponse{second}, fresh)
+}
+
+func Test_HandleNotifications(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+
+	first := nursys.NotificationLookupResponse{NcsbnID: "1", LicenseStatusChange: "Expired"}
+	second := nursys.NotificationLookupResponse{NcsbnID: "2", LicenseStatusChange: "Expired"}
+	store := nursys.NewMemorySeenStore()
+
+	var handled []string
+	failing := errors.New("downstream unavailable")
+	handler := func(_ context.Context, r nursys.NotificationLookupResponse) error {
+		if r.NcsbnID == "2" && failing != nil {
+			return failing
+		}
+		handled = append(handled, r.NcsbnID)
+		return nil
+	}
+
+	// The failed notification is not marked as seen.
+	err := nursys.HandleNotifications(ctx, store, []nursys.NotificationLookupResponse{first, first, second}, handler)
+	assert.ErrorIs(err, failing)
+	assert.Equal([]string{"1"}, handled)
+	seen, err := store.Seen(ctx, second.Fingerprint())
+	require.NoError(t, err)
+	assert.False(seen)
+
+	// A later overlapping lookup handles it, and only it.
+	failing = nil
+	err = nursys.HandleNotifications(ctx, store, []nursys.NotificationLookupResponse{first, second}, handler)
+	require.NoError(t, err)
+	assert.Equal([]string{"1", "2"}, handled)
+}
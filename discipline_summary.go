@@ -0,0 +1,202 @@
+package nursys
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DisciplineEvent is a single discipline/final order action, flattened out of the license, discipline and
+// revision report it was reported in.
+type DisciplineEvent struct {
+	License                    NurseLookupLicense          // License the discipline was reported on. Its NurseLookupDisciplines are not copied.
+	Jurisdiction               Jurisdiction                // Board that took the discipline/final orders.
+	AgainstPrivilegeToPractice bool                        // Whether the action was taken against a multistate privilege to practice.
+	BasisForActions            []NurseLookupBasisForAction // Basis for the discipline/final orders.
+	Revision                   bool                        // Whether the action comes from a revision report rather than the initial actions.
+	Date                       time.Time                   // Action date, or the discipline/revision report date if the action has none.
+	Action                     NurseLookupAction
+	InEffect                   bool // Whether the action is in effect as of the summary date.
+}
+
+// DisciplineSummary is the interpretation of a nurse's discipline/final orders as of a date.
+type DisciplineSummary struct {
+	AsOf                         time.Time
+	Timeline                     []DisciplineEvent // All actions, oldest first.
+	HasDiscipline                bool              // Whether any discipline/final orders were reported.
+	HasActiveRestriction         bool              // Whether an action restricting practice (e.g. suspension, probation, revocation) is in effect.
+	HasPrivilegeToPracticeAction bool              // Whether any discipline was taken against a multistate privilege to practice.
+}
+
+// ActiveActions returns the events of the timeline that are in effect.
+func (s DisciplineSummary) ActiveActions() []DisciplineEvent {
+	var active []DisciplineEvent
+	for _, e := range s.Timeline {
+		if e.InEffect {
+			active = append(active, e)
+		}
+	}
+	return active
+}
+
+// SummarizeDisciplines flattens the discipline/final orders on all licenses of a nurse into a timeline and
+// determines which actions are in effect as of now.
+//
+// An action is in effect if it is not stayed, has started, and has not reached its end date. Without an
+// EndDate, the end is taken from Duration: permanent and indefinite or unspecified actions remain in
+// effect, and a specified duration such as "Specified: 2 Years" or "12 months" ends that long after
+// StartDate, or ActionDate if it has none. A specified action whose length or start is not given is
+// assumed to remain in effect. When a discipline has revision reports, the actions of the latest revision
+// report supersede the earlier actions of that discipline. A reinstatement that has taken effect ends the
+// restrictive actions dated before it that the same board took on the same license, such as an indefinite
+// suspension followed by a reinstatement of the license.
+func SummarizeDisciplines(now time.Time, nurse NurseLookupResponse) DisciplineSummary {
+	summary := DisciplineSummary{AsOf: now}
+	for _, license := range nurse.NurseLookupLicenses {
+		disciplines := license.NurseLookupDisciplines
+		license.NurseLookupDisciplines = nil
+		for _, d := range disciplines {
+			summary.HasDiscipline = true
+			if d.AgainstPrivilegeToPracticeFlag {
+				summary.HasPrivilegeToPracticeAction = true
+			}
+			event := DisciplineEvent{
+				License:                    license,
+				Jurisdiction:               d.JurisdictionAbbreviation,
+				AgainstPrivilegeToPractice: d.AgainstPrivilegeToPracticeFlag,
+				BasisForActions:            d.NurseLookupBasisForActions,
+			}
+
+			// Find the latest revision report with actions, which supersedes everything before it.
+			current := -1
+			for i, rr := range d.NurseLookupRevisionReports {
+				if len(rr.NurseLookupRevisionActions) > 0 && (current < 0 ||
+					!time.Time(rr.RevisionReportDate).Before(time.Time(d.NurseLookupRevisionReports[current].RevisionReportDate))) {
+					current = i
+				}
+			}
+
+			for _, a := range d.NurseLookupInitialActions {
+				event.Action, event.Revision = a, false
+				event.Date = actionDate(a, time.Time(d.DateActionWasTaken))
+				event.InEffect = current < 0 && actionInEffect(now, a)
+				summary.Timeline = append(summary.Timeline, event)
+			}
+			for i, rr := range d.NurseLookupRevisionReports {
+				for _, a := range rr.NurseLookupRevisionActions {
+					event.Action, event.Revision = a, true
+					event.Date = actionDate(a, time.Time(rr.RevisionReportDate))
+					event.InEffect = i == current && actionInEffect(now, a)
+					summary.Timeline = append(summary.Timeline, event)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(summary.Timeline, func(i, j int) bool {
+		return summary.Timeline[i].Date.Before(summary.Timeline[j].Date)
+	})
+	endReinstated(now, summary.Timeline)
+	for _, e := range summary.Timeline {
+		if e.InEffect && isRestrictive(e.Action) {
+			summary.HasActiveRestriction = true
+		}
+	}
+	return summary
+}
+
+// endReinstated marks the restrictive actions of timeline, sorted by date, that a later reinstatement by the
+// same board on the same license has ended as not in effect.
+func endReinstated(now time.Time, timeline []DisciplineEvent) {
+	key := func(e DisciplineEvent) string {
+		l := e.License
+		return strings.Join([]string{string(l.JurisdictionAbbreviation), string(l.LicenseType), l.LicenseNumber, string(e.Jurisdiction)}, "|")
+	}
+	for i, r := range timeline {
+		if r.Action.Category() != ActionCategoryReinstatement || r.Date.After(now) || !actionStarted(now, r.Action) {
+			continue
+		}
+		for k := range timeline[:i] {
+			if e := &timeline[k]; e.InEffect && e.Date.Before(r.Date) && key(*e) == key(r) && isRestrictive(e.Action) {
+				e.InEffect = false
+			}
+		}
+	}
+}
+
+func actionDate(a NurseLookupAction, fallback time.Time) time.Time {
+	if t := time.Time(a.ActionDate); !t.IsZero() {
+		return t
+	}
+	return fallback
+}
+
+// actionStarted reports whether the action is not stayed and has started as of now.
+func actionStarted(now time.Time, a NurseLookupAction) bool {
+	if a.ActionStayedFlag {
+		return false
+	}
+	start := time.Time(a.StartDate)
+	return start.IsZero() || !start.After(now)
+}
+
+func actionInEffect(now time.Time, a NurseLookupAction) bool {
+	if !actionStarted(now, a) {
+		return false
+	}
+	end := time.Time(a.EndDate)
+	if end.IsZero() {
+		end = durationEnd(a)
+	}
+	if !end.IsZero() && end.Before(now) {
+		return false
+	}
+	return true
+}
+
+// durationEnd returns the end of an action computed from its start and specified Duration, or the zero
+// time if the action is permanent, indefinite, or its length or start is not known.
+func durationEnd(a NurseLookupAction) time.Time {
+	start := time.Time(a.StartDate)
+	if start.IsZero() {
+		start = time.Time(a.ActionDate)
+	}
+	years, months, days, ok := parseActionDuration(a.Duration)
+	if !ok || start.IsZero() {
+		return time.Time{}
+	}
+	return start.AddDate(years, months, days)
+}
+
+// parseActionDuration parses the length of a specified action Duration, a number followed by days, weeks,
+// months or years, optionally preceded by "Specified" and a separator.
+func parseActionDuration(s string) (years, months, days int, ok bool) {
+	fields := strings.Fields(strings.ToLower(strings.NewReplacer(":", " ", "-", " ", "(", " ", ")", " ").Replace(s)))
+	if len(fields) > 0 && fields[0] == "specified" {
+		fields = fields[1:]
+	}
+	if len(fields) != 2 {
+		return 0, 0, 0, false
+	}
+	n, err := strconv.Atoi(fields[0])
+	if err != nil || n <= 0 {
+		return 0, 0, 0, false
+	}
+	switch strings.TrimSuffix(fields[1], "s") {
+	case "day":
+		return 0, 0, n, true
+	case "week":
+		return 0, 0, 7 * n, true
+	case "month":
+		return 0, n, 0, true
+	case "year":
+		return n, 0, 0, true
+	}
+	return 0, 0, 0, false
+}
+
+// isRestrictive reports whether the action restricts the right to practice.
+func isRestrictive(a NurseLookupAction) bool {
+	return a.Category().Restrictive()
+}
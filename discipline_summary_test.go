@@ -0,0 +1,151 @@
+package nursys_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/connectRN/go-nursys"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SummarizeDisciplines(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	date := func(y int, m time.Month, d int) nursys.Time {
+		return nursys.Time(time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+	}
+
+	nurse := nursys.NurseLookupResponse{
+		NurseLookupLicenses: []nursys.NurseLookupLicense{
+			{
+				JurisdictionAbbreviation: "TX", LicenseType: nursys.LicenseTypeRN, LicenseNumber: "1",
+				NurseLookupDisciplines: []nursys.NurseLookupDiscipline{
+					{
+						// Suspension later revised to probation, which is still running.
+						JurisdictionAbbreviation: "TX",
+						DateActionWasTaken:       date(2022, 1, 10),
+						NurseLookupInitialActions: []nursys.NurseLookupAction{
+							{ActionDate: date(2022, 1, 10), ActionDescription: "Suspension of License"},
+						},
+						NurseLookupRevisionReports: []nursys.NurseLookupRevisionReport{{
+							RevisionReportDate: date(2023, 3, 1),
+							NurseLookupRevisionActions: []nursys.NurseLookupAction{
+								{ActionDescription: "Probation of License", StartDate: date(2023, 3, 1), EndDate: date(2025, 3, 1)},
+							},
+						}},
+					},
+					{
+						// Stayed suspension against the privilege to practice, and an old fine.
+						JurisdictionAbbreviation:       "FL",
+						AgainstPrivilegeToPracticeFlag: true,
+						DateActionWasTaken:             date(2019, 5, 1),
+						NurseLookupInitialActions: []nursys.NurseLookupAction{
+							{ActionDescription: "Suspension of License", ActionStayedFlag: true},
+							{ActionDate: date(2019, 5, 2), ActionDescription: "Publicly Available Fine"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	summary := nursys.SummarizeDisciplines(now, nurse)
+	assert.True(summary.HasDiscipline)
+	assert.True(summary.HasActiveRestriction)
+	assert.True(summary.HasPrivilegeToPracticeAction)
+
+	type event struct {
+		date     string
+		desc     string
+		revision bool
+		inEffect bool
+	}
+	var events []event
+	for _, e := range summary.Timeline {
+		events = append(events, event{e.Date.Format(time.DateOnly), e.Action.ActionDescription, e.Revision, e.InEffect})
+		assert.Equal("1", e.License.LicenseNumber)
+		assert.Nil(e.License.NurseLookupDisciplines)
+	}
+	assert.Equal([]event{
+		{"2019-05-01", "Suspension of License", false, false},
+		{"2019-05-02", "Publicly Available Fine", false, true},
+		{"2022-01-10", "Suspension of License", false, false},
+		{"2023-03-01", "Probation of License", true, true},
+	}, events)
+	assert.Len(summary.ActiveActions(), 2)
+
+	// Once the probation ends only the fine, which is not a restriction, remains.
+	later := nursys.SummarizeDisciplines(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), nurse)
+	assert.False(later.HasActiveRestriction)
+
+	assert.Equal(nursys.DisciplineSummary{AsOf: now}, nursys.SummarizeDisciplines(now, nursys.NurseLookupResponse{}))
+}
+
+func Test_SummarizeDisciplines_Duration(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	date := func(y int, m time.Month, d int) nursys.Time {
+		return nursys.Time(time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+	}
+
+	for _, tc := range []struct {
+		name     string
+		action   nursys.NurseLookupAction
+		inEffect bool
+	}{
+		{"permanent", nursys.NurseLookupAction{StartDate: date(2010, 1, 1), Duration: "Permanent"}, true},
+		{"indefinite", nursys.NurseLookupAction{StartDate: date(2010, 1, 1), Duration: "Indefinite/Unspecified"}, true},
+		{"specified running", nursys.NurseLookupAction{StartDate: date(2023, 1, 1), Duration: "Specified: 2 Years"}, true},
+		{"specified ended", nursys.NurseLookupAction{StartDate: date(2022, 1, 1), Duration: "Specified: 2 Years"}, false},
+		{"specified from action date", nursys.NurseLookupAction{ActionDate: date(2023, 1, 1), Duration: "12 months"}, false},
+		{"specified without length", nursys.NurseLookupAction{StartDate: date(2010, 1, 1), Duration: "Specified"}, true},
+		{"end date wins", nursys.NurseLookupAction{StartDate: date(2022, 1, 1), EndDate: date(2025, 1, 1), Duration: "1 year"}, true},
+	} {
+		tc.action.ActionDescription = "Probation of License"
+		nurse := nursys.NurseLookupResponse{NurseLookupLicenses: []nursys.NurseLookupLicense{{
+			NurseLookupDisciplines: []nursys.NurseLookupDiscipline{{NurseLookupInitialActions: []nursys.NurseLookupAction{tc.action}}},
+		}}}
+		summary := nursys.SummarizeDisciplines(now, nurse)
+		assert.Equal(t, tc.inEffect, summary.Timeline[0].InEffect, tc.name)
+		assert.Equal(t, tc.inEffect, summary.HasActiveRestriction, tc.name)
+	}
+}
+
+func Test_SummarizeDisciplines_Reinstatement(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	date := func(y int, m time.Month, d int) nursys.Time {
+		return nursys.Time(time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+	}
+	discipline := func(board nursys.Jurisdiction, code nursys.NPDBActionCode, actionDate nursys.Time) nursys.NurseLookupDiscipline {
+		return nursys.NurseLookupDiscipline{
+			JurisdictionAbbreviation:  board,
+			DateActionWasTaken:        actionDate,
+			NurseLookupInitialActions: []nursys.NurseLookupAction{{ActionCode: code, ActionDate: actionDate, Duration: "Indefinite"}},
+		}
+	}
+	nurse := func(disciplines ...nursys.NurseLookupDiscipline) nursys.NurseLookupResponse {
+		return nursys.NurseLookupResponse{NurseLookupLicenses: []nursys.NurseLookupLicense{{
+			JurisdictionAbbreviation: "TX", LicenseType: nursys.LicenseTypeRN, LicenseNumber: "1",
+			NurseLookupDisciplines: disciplines,
+		}}}
+	}
+	suspension := discipline("TX", nursys.NPDBActionSuspension, date(2015, 4, 1))
+
+	// A later reinstatement by the same board ends the indefinite suspension.
+	summary := nursys.SummarizeDisciplines(now, nurse(suspension, discipline("TX", nursys.NPDBActionReinstatement, date(2018, 9, 1))))
+	assert.False(summary.HasActiveRestriction)
+	assert.Len(summary.ActiveActions(), 1)
+
+	// A reinstatement by another board, or one not yet reached, does not.
+	assert.True(nursys.SummarizeDisciplines(now, nurse(suspension, discipline("FL", nursys.NPDBActionReinstatement, date(2018, 9, 1)))).HasActiveRestriction)
+	assert.True(nursys.SummarizeDisciplines(now, nurse(suspension, discipline("TX", nursys.NPDBActionReinstatement, date(2024, 9, 1)))).HasActiveRestriction)
+
+	// Restrictions after the reinstatement stay in effect.
+	summary = nursys.SummarizeDisciplines(now, nurse(suspension, discipline("TX", nursys.NPDBActionReinstatement, date(2018, 9, 1)),
+		discipline("TX", nursys.NPDBActionProbation, date(2020, 1, 1))))
+	assert.True(summary.HasActiveRestriction)
+
+	// A denial of reinstatement is not a reinstatement.
+	assert.True(nursys.SummarizeDisciplines(now, nurse(suspension, discipline("TX", nursys.NPDBActionDenialOfReinstatement, date(2018, 9, 1)))).HasActiveRestriction)
+}
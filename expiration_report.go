@@ -0,0 +1,158 @@
+package nursys
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// DefaultExpirationWindows are the reporting windows, in days, used by BuildExpirationReport when none are given.
+var DefaultExpirationWindows = []int{30, 60, 90}
+
+// ExpirationKind tells what expires in an ExpirationReportEntry.
+type ExpirationKind string
+
+// Expiration kinds
+const (
+	ExpirationKindLicense        ExpirationKind = "License"        // NurseLookupLicense.LicenseExpirationDate
+	ExpirationKindCertification  ExpirationKind = "Certification"  // NurseLookupAdvancedPractice.CertificationExpirationDate
+	ExpirationKindFocusSpecialty ExpirationKind = "FocusSpecialty" // NurseLookupAdvancedPractice.FocusSpecialtyExpirationDate
+)
+
+// ExpirationReportEntry is a license, APRN certification or APRN focus/specialty that expires within the
+// reporting windows.
+type ExpirationReportEntry struct {
+	NcsbnID        string         `json:"NcsbnId"`
+	RecordID       string         `json:"RecordId"` // Client-provided identifier from the lookup request.
+	FirstName      string         `json:"FirstName"`
+	LastName       string         `json:"LastName"`
+	Jurisdiction   Jurisdiction   `json:"JurisdictionAbbreviation"`
+	LicenseType    LicenseType    `json:"LicenseType"`
+	LicenseNumber  string         `json:"LicenseNumber"`
+	Kind           ExpirationKind `json:"Kind"`
+	FocusSpecialty string         `json:"FocusSpecialty,omitempty"` // For APRN certifications and focus/specialties.
+	ExpirationDate time.Time      `json:"ExpirationDate"`
+	DaysRemaining  int            `json:"DaysRemaining"` // Calendar days from the report date; 0 means it expires today.
+	Window         int            `json:"Window"`        // Smallest reporting window, in days, containing the expiration.
+}
+
+// ExpirationReport lists upcoming expirations, ordered by nurse, jurisdiction, license type, license number
+// and expiration date.
+type ExpirationReport struct {
+	AsOf    time.Time               `json:"AsOf"`
+	Entries []ExpirationReportEntry `json:"Entries"`
+}
+
+// BuildExpirationReport evaluates every license and APRN certification and focus/specialty in the Nurse Lookup
+// results and reports those expiring within the largest of windows (in days) from now. Already expired items
+// and items without a recognized expiration date are left out. A nil windows means DefaultExpirationWindows.
+func BuildExpirationReport(now time.Time, windows []int, results ...NurseLookupRetrieveResponseMessage) ExpirationReport {
+	if len(windows) == 0 {
+		windows = DefaultExpirationWindows
+	}
+	windows = append([]int(nil), windows...)
+	sort.Ints(windows)
+
+	report := ExpirationReport{AsOf: now}
+	add := func(nurse NurseLookupResponse, license NurseLookupLicense, kind ExpirationKind, focus string, exp time.Time) {
+		days := daysBetween(now, exp)
+		i := sort.SearchInts(windows, days)
+		if days < 0 || i == len(windows) {
+			return
+		}
+		report.Entries = append(report.Entries, ExpirationReportEntry{
+			NcsbnID:        nurse.NcsbnID,
+			RecordID:       nurse.NurseLookupRequest.RecordID,
+			FirstName:      nurse.FirstName,
+			LastName:       nurse.LastName,
+			Jurisdiction:   license.JurisdictionAbbreviation,
+			LicenseType:    license.LicenseType,
+			LicenseNumber:  license.LicenseNumber,
+			Kind:           kind,
+			FocusSpecialty: focus,
+			ExpirationDate: exp,
+			DaysRemaining:  days,
+			Window:         windows[i],
+		})
+	}
+
+	for _, result := range results {
+		for _, nurse := range result.NurseLookupResponses {
+			for _, license := range nurse.NurseLookupLicenses {
+				if exp, ok := license.ExpirationDate(); ok {
+					add(nurse, license, ExpirationKindLicense, "", exp)
+				}
+				for _, ap := range license.NurseLookupAdvancedPractices {
+					if exp := time.Time(ap.CertificationExpirationDate); !exp.IsZero() {
+						add(nurse, license, ExpirationKindCertification, ap.FocusSpecialty, exp)
+					}
+					if exp := time.Time(ap.FocusSpecialtyExpirationDate); !exp.IsZero() {
+						add(nurse, license, ExpirationKindFocusSpecialty, ap.FocusSpecialty, exp)
+					}
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(report.Entries, func(i, j int) bool {
+		a, b := report.Entries[i], report.Entries[j]
+		switch {
+		case a.LastName != b.LastName:
+			return a.LastName < b.LastName
+		case a.FirstName != b.FirstName:
+			return a.FirstName < b.FirstName
+		case a.NcsbnID != b.NcsbnID:
+			return a.NcsbnID < b.NcsbnID
+		case a.Jurisdiction != b.Jurisdiction:
+			return a.Jurisdiction < b.Jurisdiction
+		case a.LicenseType != b.LicenseType:
+			return a.LicenseType < b.LicenseType
+		case a.LicenseNumber != b.LicenseNumber:
+			return a.LicenseNumber < b.LicenseNumber
+		}
+		return a.ExpirationDate.Before(b.ExpirationDate)
+	})
+	return report
+}
+
+// WriteCSV writes the report entries to w as CSV with a header row.
+func (r ExpirationReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"NcsbnId", "RecordId", "LastName", "FirstName", "JurisdictionAbbreviation", "LicenseType", "LicenseNumber",
+		"Kind", "FocusSpecialty", "ExpirationDate", "DaysRemaining", "Window"})
+	for _, e := range r.Entries {
+		cw.Write([]string{
+			e.NcsbnID,
+			e.RecordID,
+			e.LastName,
+			e.FirstName,
+			string(e.Jurisdiction),
+			string(e.LicenseType),
+			e.LicenseNumber,
+			string(e.Kind),
+			e.FocusSpecialty,
+			e.ExpirationDate.Format(time.DateOnly),
+			strconv.Itoa(e.DaysRemaining),
+			strconv.Itoa(e.Window),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the report to w as JSON.
+func (r ExpirationReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// daysBetween returns the number of calendar days from the date of a to the date of b.
+func daysBetween(a, b time.Time) int {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return int(time.Date(by, bm, bd, 0, 0, 0, 0, time.UTC).Sub(time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC)).Hours() / 24)
+}
@@ -0,0 +1,70 @@
+package nursys_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/connectRN/go-nursys"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BuildExpirationReport(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC)
+	date := func(days int) nursys.Time { return nursys.Time(now.AddDate(0, 0, days)) }
+
+	result := nursys.NurseLookupRetrieveResponseMessage{
+		NurseLookupResponses: []nursys.NurseLookupResponse{
+			{
+				NcsbnID: "2", FirstName: "Zoe", LastName: "Young",
+				NurseLookupLicenses: []nursys.NurseLookupLicense{
+					{JurisdictionAbbreviation: "TX", LicenseType: nursys.LicenseTypeRN, LicenseNumber: "9", LicenseExpirationDate: "01/31/2024"},
+				},
+			},
+			{
+				NcsbnID: "1", FirstName: "Al", LastName: "Adams",
+				NurseLookupLicenses: []nursys.NurseLookupLicense{
+					{JurisdictionAbbreviation: "NY", LicenseType: nursys.LicenseTypeRN, LicenseNumber: "1", LicenseExpirationDate: "2024-12-31"}, // Too far out
+					{JurisdictionAbbreviation: "NY", LicenseType: nursys.LicenseTypeRN, LicenseNumber: "2", LicenseExpirationDate: "2023-12-31"}, // Expired
+					{
+						JurisdictionAbbreviation: "NY", LicenseType: nursys.LicenseTypeCNP, LicenseNumber: "3", LicenseExpirationDate: "2024-01-01",
+						NurseLookupAdvancedPractices: []nursys.NurseLookupAdvancedPractice{
+							{FocusSpecialty: "Family", CertificationExpirationDate: date(45), FocusSpecialtyExpirationDate: date(90)},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	report := nursys.BuildExpirationReport(now, nil, result)
+	type row struct {
+		number string
+		kind   nursys.ExpirationKind
+		days   int
+		window int
+	}
+	var rows []row
+	for _, e := range report.Entries {
+		rows = append(rows, row{e.LicenseNumber, e.Kind, e.DaysRemaining, e.Window})
+	}
+	assert.Equal([]row{
+		{"3", nursys.ExpirationKindLicense, 0, 30},
+		{"3", nursys.ExpirationKindCertification, 45, 60},
+		{"3", nursys.ExpirationKindFocusSpecialty, 90, 90},
+		{"9", nursys.ExpirationKindLicense, 30, 30},
+	}, rows)
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteCSV(&buf))
+	assert.Equal("NcsbnId,RecordId,LastName,FirstName,JurisdictionAbbreviation,LicenseType,LicenseNumber,Kind,FocusSpecialty,ExpirationDate,DaysRemaining,Window\n"+
+		"1,,Adams,Al,NY,CNP,3,License,,2024-01-01,0,30\n"+
+		"1,,Adams,Al,NY,CNP,3,Certification,Family,2024-02-15,45,60\n"+
+		"1,,Adams,Al,NY,CNP,3,FocusSpecialty,Family,2024-03-31,90,90\n"+
+		"2,,Young,Zoe,TX,RN,9,License,,2024-01-31,30,30\n", buf.String())
+
+	assert.Len(nursys.BuildExpirationReport(now, []int{7}, result).Entries, 1)
+}
@@ -0,0 +1,26 @@
+package nursys
+
+import (
+	"reflect"
+	"strings"
+)
+
+// jsonFieldName returns the name a struct field has in the Nursys JSON API.
+func jsonFieldName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return f.Name
+	}
+	return name
+}
+
+// fieldByJSONName returns the field of the struct v whose JSON name matches name, ignoring case.
+func fieldByJSONName(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if strings.EqualFold(jsonFieldName(t.Field(i)), name) {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
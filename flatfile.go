@@ -0,0 +1,309 @@
+package nursys
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// FlatFileField is a fixed-width column of an e-Notify flat file.
+type FlatFileField struct {
+	Name  string // API field name (e.g. "LicenseNumber"). Nested fields are separated by dots, e.g. "ManageNurseListRequest.RecordId".
+	Width int    // Width in characters.
+	// Format of Time and bool values. For Time fields it is a time layout, such as "01/02/2006", and
+	// time.RFC3339 if empty. For bool fields it is the true and false values separated by a slash, such
+	// as "1/0", and "Y/N" if empty.
+	Format string
+}
+
+// FlatFileLayout is the ordered list of columns making up one record of an e-Notify flat file.
+//
+// The layouts returned by NurseListFileLayout, NurseListResultFileLayout and NotificationFileLayout use the
+// field order and lengths of the corresponding objects of the e-Notify File and API Specifications v3.1.2,
+// with dates in YYYY-MM-DD format and flags as Y or N. Institutions whose e-Notify account is configured
+// with a different layout can pass their own FlatFileLayout. Files with header and trailer records are read
+// and written with one encoder or decoder per record layout, or by handling those lines separately.
+type FlatFileLayout []FlatFileField
+
+// NurseListFileLayout returns the layout of a nurse list upload file, one ManageNurseListRequest per
+// 458-character record. The comments give the position of the first character of each column.
+func NurseListFileLayout() FlatFileLayout {
+	return FlatFileLayout{
+		{Name: "SubmissionActionCode", Width: 1},          // 1
+		{Name: "JurisdictionAbbreviation", Width: 4},      // 2
+		{Name: "LicenseNumber", Width: 15},                // 6
+		{Name: "LicenseType", Width: 4},                   // 21
+		{Name: "NcsbnId", Width: 10},                      // 25
+		{Name: "Email", Width: 50},                        // 35
+		{Name: "Address1", Width: 50},                     // 85
+		{Name: "Address2", Width: 50},                     // 135
+		{Name: "City", Width: 50},                         // 185
+		{Name: "State", Width: 2},                         // 235
+		{Name: "Zip", Width: 10},                          // 237
+		{Name: "LastFourSSN", Width: 4},                   // 247
+		{Name: "BirthYear", Width: 4},                     // 251
+		{Name: "HospitalPracticeSetting", Width: 2},       // 255
+		{Name: "HospitalPracticeSettingOther", Width: 50}, // 257
+		{Name: "NotificationsEnabled", Width: 1},          // 307
+		{Name: "RemindersEnabled", Width: 1},              // 308
+		{Name: "RecordId", Width: 50},                     // 309
+		{Name: "LocationList", Width: 100},                // 359
+	}
+}
+
+// NurseListResultFileLayout returns the layout of a nurse list result file, one MangeNurseListResponse per
+// 585-character record. Errors are written as "<ErrorID> <ErrorMessage>" entries separated by semicolons.
+func NurseListResultFileLayout() FlatFileLayout {
+	return FlatFileLayout{
+		{Name: "ManageNurseListRequest.RecordId", Width: 50},                // 1
+		{Name: "ManageNurseListRequest.SubmissionActionCode", Width: 1},     // 51
+		{Name: "ManageNurseListRequest.JurisdictionAbbreviation", Width: 4}, // 52
+		{Name: "ManageNurseListRequest.LicenseNumber", Width: 15},           // 56
+		{Name: "ManageNurseListRequest.LicenseType", Width: 4},              // 71
+		{Name: "ManageNurseListRequest.NcsbnId", Width: 10},                 // 75
+		{Name: "SuccessFlag", Width: 1},                                     // 85
+		{Name: "Errors", Width: 500},                                        // 86
+	}
+}
+
+// NotificationFileLayout returns the layout of a notification file, one NotificationLookupResponse per
+// 1743-character record. NotificationDate is written as YYYY-MM-DD.
+func NotificationFileLayout() FlatFileLayout {
+	return FlatFileLayout{
+		{Name: "NcsbnId", Width: 10},                                 // 1
+		{Name: "JurisdictionAbbreviation", Width: 4},                 // 11
+		{Name: "Jurisdiction", Width: 50},                            // 15
+		{Name: "LicenseNumber", Width: 15},                           // 65
+		{Name: "LicenseType", Width: 4},                              // 80
+		{Name: "FirstName", Width: 50},                               // 84
+		{Name: "LastName", Width: 50},                                // 134
+		{Name: "RecordId", Width: 50},                                // 184
+		{Name: "NotificationDate", Width: 10, Format: time.DateOnly}, // 234
+		{Name: "LicenseStatusChange", Width: 500},                    // 244
+		{Name: "DisciplineStatusChange", Width: 500},                 // 744
+		{Name: "DisciplineStatusChangeOther", Width: 500},            // 1244
+	}
+}
+
+// RecordWidth returns the total width of a record.
+func (l FlatFileLayout) RecordWidth() int {
+	width := 0
+	for _, f := range l {
+		width += f.Width
+	}
+	return width
+}
+
+// FlatFileEncoder writes records to a fixed-width flat file.
+type FlatFileEncoder struct {
+	w      *bufio.Writer
+	layout FlatFileLayout
+}
+
+// NewFlatFileEncoder returns an encoder writing records with the given layout to w.
+// Call Flush when done.
+func NewFlatFileEncoder(w io.Writer, layout FlatFileLayout) *FlatFileEncoder {
+	return &FlatFileEncoder{w: bufio.NewWriter(w), layout: layout}
+}
+
+// Encode writes v, a struct or pointer to struct, as one record. Values longer than their column
+// are an error.
+func (e *FlatFileEncoder) Encode(v interface{}) error {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	var sb strings.Builder
+	for _, col := range e.layout {
+		f, err := flatFileField(rv, col.Name)
+		if err != nil {
+			return err
+		}
+		s, err := formatFlatFileValue(f, col.Format)
+		if err != nil {
+			return fmt.Errorf("nursys: field %s: %w", col.Name, err)
+		}
+		n := utf8.RuneCountInString(s)
+		if n > col.Width {
+			return fmt.Errorf("nursys: field %s: value is %d characters, column is %d", col.Name, n, col.Width)
+		}
+		sb.WriteString(s)
+		sb.WriteString(strings.Repeat(" ", col.Width-n))
+	}
+	sb.WriteString("\r\n")
+	_, err := e.w.WriteString(sb.String())
+	return err
+}
+
+// Flush writes any buffered records to the underlying writer.
+func (e *FlatFileEncoder) Flush() error {
+	return e.w.Flush()
+}
+
+// FlatFileDecoder reads records from a fixed-width flat file.
+type FlatFileDecoder struct {
+	s      *bufio.Scanner
+	layout FlatFileLayout
+	line   int
+}
+
+// NewFlatFileDecoder returns a decoder reading records with the given layout from r.
+func NewFlatFileDecoder(r io.Reader, layout FlatFileLayout) *FlatFileDecoder {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, 64*1024), 4*layout.RecordWidth()+2) // Up to 4 bytes per character, plus CRLF
+	return &FlatFileDecoder{s: s, layout: layout}
+}
+
+// Decode reads the next record into v, which must be a pointer to a struct. Blank lines are skipped and
+// trailing spaces are trimmed from every value. At the end of the input Decode returns io.EOF.
+func (d *FlatFileDecoder) Decode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("nursys: Decode requires a pointer to a struct")
+	}
+	var line string
+	for line == "" {
+		if !d.s.Scan() {
+			if err := d.s.Err(); err != nil {
+				return err
+			}
+			return io.EOF
+		}
+		d.line++
+		line = strings.TrimRight(d.s.Text(), "\r")
+	}
+
+	rest := line
+	for _, col := range d.layout {
+		var value string
+		value, rest = cutRunes(rest, col.Width)
+		f, err := flatFileField(rv.Elem(), col.Name)
+		if err != nil {
+			return err
+		}
+		if err := parseFlatFileValue(f, strings.TrimRight(value, " "), col.Format); err != nil {
+			return fmt.Errorf("nursys: line %d: field %s: %w", d.line, col.Name, err)
+		}
+	}
+	return nil
+}
+
+// flatFileField resolves a dotted field name against the struct v.
+func flatFileField(v reflect.Value, name string) (reflect.Value, error) {
+	for _, part := range strings.Split(name, ".") {
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Errorf("nursys: layout field %s: %s is not a struct", name, part)
+		}
+		f, ok := fieldByJSONName(v, part)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("nursys: layout field %s: no field %s in %s", name, part, v.Type())
+		}
+		v = f
+	}
+	return v, nil
+}
+
+var (
+	timeType              = reflect.TypeOf(Time{})
+	transactionErrorsType = reflect.TypeOf([]TransactionError(nil))
+)
+
+// flatFileFlags returns the true and false values of a bool column format.
+func flatFileFlags(format string) (string, string) {
+	if t, f, ok := strings.Cut(format, "/"); ok {
+		return t, f
+	}
+	return "Y", "N"
+}
+
+func formatFlatFileValue(f reflect.Value, format string) (string, error) {
+	switch {
+	case f.Type() == timeType:
+		t := time.Time(f.Interface().(Time))
+		if t.IsZero() {
+			return "", nil
+		}
+		if format == "" {
+			format = time.RFC3339
+		}
+		return t.Format(format), nil
+	case f.Type() == transactionErrorsType:
+		var entries []string
+		for _, e := range f.Interface().([]TransactionError) {
+			entries = append(entries, fmt.Sprintf("%d %s", e.ErrorID, strings.TrimSpace(e.ErrorMessage)))
+		}
+		return strings.Join(entries, "; "), nil
+	case f.Kind() == reflect.String:
+		return f.String(), nil
+	case f.Kind() == reflect.Bool:
+		yes, no := flatFileFlags(format)
+		if f.Bool() {
+			return yes, nil
+		}
+		return no, nil
+	}
+	return "", fmt.Errorf("unsupported type %s", f.Type())
+}
+
+func parseFlatFileValue(f reflect.Value, s, format string) error {
+	switch {
+	case f.Type() == timeType:
+		if s == "" {
+			f.Set(reflect.ValueOf(Time{}))
+			return nil
+		}
+		if format == "" {
+			format = time.RFC3339
+		}
+		t, err := time.Parse(format, s)
+		if err != nil {
+			return err
+		}
+		f.Set(reflect.ValueOf(Time(t)))
+	case f.Type() == transactionErrorsType:
+		var errs []TransactionError
+		for _, entry := range strings.Split(s, ";") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			idStr, msg, _ := strings.Cut(entry, " ")
+			id, err := strconv.ParseInt(idStr, 10, 64)
+			if err != nil {
+				id, msg = 0, entry
+			}
+			errs = append(errs, TransactionError{ErrorID: id, ErrorMessage: msg})
+		}
+		f.Set(reflect.ValueOf(errs))
+	case f.Kind() == reflect.String:
+		f.SetString(s)
+	case f.Kind() == reflect.Bool:
+		yes, no := flatFileFlags(format)
+		switch s {
+		case yes:
+			f.SetBool(true)
+		case no, "":
+			f.SetBool(false)
+		default:
+			return fmt.Errorf("invalid flag %q", s)
+		}
+	default:
+		return fmt.Errorf("unsupported type %s", f.Type())
+	}
+	return nil
+}
+
+// cutRunes splits s after n characters.
+func cutRunes(s string, n int) (head, tail string) {
+	i := 0
+	for pos := range s {
+		if i == n {
+			return s[:pos], s[pos:]
+		}
+		i++
+	}
+	return s, ""
+}
@@ -0,0 +1,207 @@
+package nursys_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/connectRN/go-nursys"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Layouts for the tests. They are not the layouts of the e-Notify File Specification.
+var (
+	testNurseListLayout = nursys.FlatFileLayout{
+		{Name: "SubmissionActionCode", Width: 1},
+		{Name: "JurisdictionAbbreviation", Width: 4},
+		{Name: "LicenseNumber", Width: 15},
+		{Name: "LicenseType", Width: 4},
+		{Name: "NcsbnId", Width: 10},
+		{Name: "Address1", Width: 50},
+		{Name: "City", Width: 50},
+		{Name: "State", Width: 2},
+		{Name: "Zip", Width: 10},
+		{Name: "LastFourSSN", Width: 4},
+		{Name: "BirthYear", Width: 4},
+		{Name: "NotificationsEnabled", Width: 1},
+		{Name: "RemindersEnabled", Width: 1},
+	}
+	testResultLayout = nursys.FlatFileLayout{
+		{Name: "ManageNurseListRequest.RecordId", Width: 50},
+		{Name: "ManageNurseListRequest.SubmissionActionCode", Width: 1},
+		{Name: "ManageNurseListRequest.JurisdictionAbbreviation", Width: 4},
+		{Name: "ManageNurseListRequest.LicenseNumber", Width: 15},
+		{Name: "ManageNurseListRequest.LicenseType", Width: 4},
+		{Name: "SuccessFlag", Width: 1, Format: "1/0"},
+		{Name: "Errors", Width: 500},
+	}
+)
+
+func Test_FlatFile_NurseList(t *testing.T) {
+	assert := assert.New(t)
+	layout := testNurseListLayout
+
+	first, second := testNurse("123456"), testNurse("234567")
+	first.SubmissionActionCode = nursys.ActionCodeAdd
+	second.SubmissionActionCode = nursys.ActionCodeRemove
+	second.City = "Saratoga Springs"
+
+	var buf bytes.Buffer
+	enc := nursys.NewFlatFileEncoder(&buf, layout)
+	require.NoError(t, enc.Encode(first))
+	require.NoError(t, enc.Encode(&second))
+	require.NoError(t, enc.Flush())
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	require.Len(t, lines, 2)
+	assert.Len(lines[0], layout.RecordWidth())
+	assert.True(strings.HasPrefix(lines[0], "ANY  123456         RN  "), lines[0])
+
+	dec := nursys.NewFlatFileDecoder(&buf, layout)
+	var got nursys.ManageNurseListRequest
+	require.NoError(t, dec.Decode(&got))
+	assert.Equal(first, got)
+	got = nursys.ManageNurseListRequest{}
+	require.NoError(t, dec.Decode(&got))
+	assert.Equal(second, got)
+	assert.Equal(io.EOF, dec.Decode(&got))
+
+	second.City = strings.Repeat("x", 51)
+	assert.EqualError(enc.Encode(second), "nursys: field City: value is 51 characters, column is 50")
+}
+
+func Test_FlatFile_Results(t *testing.T) {
+	req := nursys.ManageNurseListRequest{SubmissionActionCode: nursys.ActionCodeAdd, JurisdictionAbbreviation: "NY", LicenseType: "RN", LicenseNumber: "123456", RecordID: "emp-1"}
+	results := []nursys.MangeNurseListResponse{
+		{SuccessFlag: true, ManageNurseListRequest: req},
+		{SuccessFlag: false, ManageNurseListRequest: req, Errors: []nursys.TransactionError{{ErrorID: 12, ErrorMessage: "Invalid zip."}, {ErrorID: 14, ErrorMessage: "Invalid birth year."}}},
+	}
+
+	var buf bytes.Buffer
+	enc := nursys.NewFlatFileEncoder(&buf, testResultLayout)
+	for _, r := range results {
+		require.NoError(t, enc.Encode(r))
+	}
+	require.NoError(t, enc.Flush())
+
+	dec := nursys.NewFlatFileDecoder(&buf, testResultLayout)
+	for _, want := range results {
+		var got nursys.MangeNurseListResponse
+		require.NoError(t, dec.Decode(&got))
+		assert.Equal(t, want, got)
+	}
+}
+
+func Test_FlatFile_Notifications(t *testing.T) {
+	want := nursys.NotificationLookupResponse{
+		NcsbnID:                  "12345678",
+		JurisdictionAbbreviation: "NY",
+		LicenseNumber:            "123456",
+		LicenseType:              "RN",
+		FirstName:                "José",
+		LastName:                 "Núñez",
+		NotificationDate:         nursys.Time(time.Date(2024, 1, 4, 13, 45, 30, 0, time.UTC)),
+		LicenseStatusChange:      "Expired",
+	}
+
+	for _, tc := range []struct {
+		format string
+		date   string
+	}{
+		{"", "2024-01-04T13:45:30Z"},
+		{"01/02/2006 15:04:05", "01/04/2024 13:45:30"},
+	} {
+		layout := nursys.FlatFileLayout{
+			{Name: "NcsbnId", Width: 10},
+			{Name: "JurisdictionAbbreviation", Width: 4},
+			{Name: "LicenseNumber", Width: 15},
+			{Name: "LicenseType", Width: 4},
+			{Name: "FirstName", Width: 50},
+			{Name: "LastName", Width: 50},
+			{Name: "NotificationDate", Width: 25, Format: tc.format},
+			{Name: "LicenseStatusChange", Width: 500},
+		}
+
+		var buf bytes.Buffer
+		enc := nursys.NewFlatFileEncoder(&buf, layout)
+		require.NoError(t, enc.Encode(want))
+		require.NoError(t, enc.Flush())
+		assert.Contains(t, buf.String(), tc.date)
+
+		var got nursys.NotificationLookupResponse
+		require.NoError(t, nursys.NewFlatFileDecoder(&buf, layout).Decode(&got))
+		assert.Equal(t, want, got, tc.format)
+	}
+}
+
+func Test_FlatFile_SpecLayouts(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(458, nursys.NurseListFileLayout().RecordWidth())
+	assert.Equal(585, nursys.NurseListResultFileLayout().RecordWidth())
+	assert.Equal(1743, nursys.NotificationFileLayout().RecordWidth())
+
+	pad := func(s string, width int) string { return s + strings.Repeat(" ", width-len([]rune(s))) }
+	for _, tc := range []struct {
+		name   string
+		layout nursys.FlatFileLayout
+		record string
+		value  interface{}
+		want   interface{}
+	}{
+		{
+			name:   "nurse list",
+			layout: nursys.NurseListFileLayout(),
+			record: "A" + pad("NY", 4) + pad("123456", 15) + pad("RN", 4) + pad("12345678", 10) + pad("", 50) +
+				pad("1 Main St", 50) + pad("Apt 2", 50) + pad("Albany", 50) + "NY" + pad("12207", 10) + "1234" + "1980" +
+				"01" + pad("", 50) + "Y" + "N" + pad("emp-1", 50) + pad("ALB|SAR", 100),
+			value: &nursys.ManageNurseListRequest{},
+			want: &nursys.ManageNurseListRequest{
+				SubmissionActionCode: nursys.ActionCodeAdd, JurisdictionAbbreviation: "NY", LicenseNumber: "123456",
+				LicenseType: nursys.LicenseTypeRN, NcsbnID: "12345678", Address1: "1 Main St", Address2: "Apt 2",
+				City: "Albany", State: "NY", Zip: "12207", LastFourSSN: "1234", BirthYear: "1980",
+				HospitalPracticeSetting: "01", NotificationsEnabled: "Y", RemindersEnabled: "N", RecordID: "emp-1",
+				LocationList: "ALB|SAR",
+			},
+		},
+		{
+			name:   "result",
+			layout: nursys.NurseListResultFileLayout(),
+			record: pad("emp-1", 50) + "A" + pad("NY", 4) + pad("123456", 15) + pad("RN", 4) + pad("12345678", 10) + "N" +
+				pad("12 Invalid zip.; 14 Invalid birth year.", 500),
+			value: &nursys.MangeNurseListResponse{},
+			want: &nursys.MangeNurseListResponse{
+				ManageNurseListRequest: nursys.ManageNurseListRequest{
+					RecordID: "emp-1", SubmissionActionCode: nursys.ActionCodeAdd, JurisdictionAbbreviation: "NY",
+					LicenseNumber: "123456", LicenseType: nursys.LicenseTypeRN, NcsbnID: "12345678",
+				},
+				Errors: []nursys.TransactionError{{ErrorID: 12, ErrorMessage: "Invalid zip."}, {ErrorID: 14, ErrorMessage: "Invalid birth year."}},
+			},
+		},
+		{
+			name:   "notification",
+			layout: nursys.NotificationFileLayout(),
+			record: pad("12345678", 10) + pad("NY", 4) + pad("New York", 50) + pad("123456", 15) + pad("RN", 4) +
+				pad("Jane", 50) + pad("Doe", 50) + pad("emp-1", 50) + "2024-01-04" + pad("Expired", 500) + pad("", 500) + pad("", 500),
+			value: &nursys.NotificationLookupResponse{},
+			want: &nursys.NotificationLookupResponse{
+				NcsbnID: "12345678", JurisdictionAbbreviation: "NY", Jurisdiction: "New York", LicenseNumber: "123456",
+				LicenseType: nursys.LicenseTypeRN, FirstName: "Jane", LastName: "Doe", RecordID: "emp-1",
+				NotificationDate: nursys.Time(time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)), LicenseStatusChange: "Expired",
+			},
+		},
+	} {
+		require.Len(t, tc.record, tc.layout.RecordWidth(), tc.name)
+		require.NoError(t, nursys.NewFlatFileDecoder(strings.NewReader(tc.record+"\r\n"), tc.layout).Decode(tc.value), tc.name)
+		assert.Equal(tc.want, tc.value, tc.name)
+
+		var buf bytes.Buffer
+		enc := nursys.NewFlatFileEncoder(&buf, tc.layout)
+		require.NoError(t, enc.Encode(tc.value), tc.name)
+		require.NoError(t, enc.Flush(), tc.name)
+		assert.Equal(tc.record+"\r\n", buf.String(), tc.name)
+	}
+}
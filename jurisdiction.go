@@ -0,0 +1,196 @@
+package nursys
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Jurisdiction is the abbreviation of a state board of nursing, as used in the JurisdictionAbbreviation fields.
+// States with separate boards for registered and practical/vocational nurses have one jurisdiction per board.
+type Jurisdiction string
+
+// Jurisdictions from the API Spec Appendix
+const (
+	JurisdictionAK   Jurisdiction = "AK"
+	JurisdictionAL   Jurisdiction = "AL"
+	JurisdictionAR   Jurisdiction = "AR"
+	JurisdictionAS   Jurisdiction = "AS" // American Samoa
+	JurisdictionAZ   Jurisdiction = "AZ"
+	JurisdictionCARN Jurisdiction = "CARN" // California Board of Registered Nursing
+	JurisdictionCAVN Jurisdiction = "CAVN" // California Board of Vocational Nursing and Psychiatric Technicians
+	JurisdictionCO   Jurisdiction = "CO"
+	JurisdictionCT   Jurisdiction = "CT"
+	JurisdictionDC   Jurisdiction = "DC"
+	JurisdictionDE   Jurisdiction = "DE"
+	JurisdictionFL   Jurisdiction = "FL"
+	JurisdictionGA   Jurisdiction = "GA"
+	JurisdictionGU   Jurisdiction = "GU" // Guam
+	JurisdictionHI   Jurisdiction = "HI"
+	JurisdictionIA   Jurisdiction = "IA"
+	JurisdictionID   Jurisdiction = "ID"
+	JurisdictionIL   Jurisdiction = "IL"
+	JurisdictionIN   Jurisdiction = "IN"
+	JurisdictionKS   Jurisdiction = "KS"
+	JurisdictionKY   Jurisdiction = "KY"
+	JurisdictionLAPN Jurisdiction = "LAPN" // Louisiana State Board of Practical Nurse Examiners
+	JurisdictionLARN Jurisdiction = "LARN" // Louisiana State Board of Nursing
+	JurisdictionMA   Jurisdiction = "MA"
+	JurisdictionMD   Jurisdiction = "MD"
+	JurisdictionME   Jurisdiction = "ME"
+	JurisdictionMI   Jurisdiction = "MI"
+	JurisdictionMN   Jurisdiction = "MN"
+	JurisdictionMO   Jurisdiction = "MO"
+	JurisdictionMP   Jurisdiction = "MP" // Northern Mariana Islands
+	JurisdictionMS   Jurisdiction = "MS"
+	JurisdictionMT   Jurisdiction = "MT"
+	JurisdictionNC   Jurisdiction = "NC"
+	JurisdictionND   Jurisdiction = "ND"
+	JurisdictionNE   Jurisdiction = "NE"
+	JurisdictionNH   Jurisdiction = "NH"
+	JurisdictionNJ   Jurisdiction = "NJ"
+	JurisdictionNM   Jurisdiction = "NM"
+	JurisdictionNV   Jurisdiction = "NV"
+	JurisdictionNY   Jurisdiction = "NY"
+	JurisdictionOH   Jurisdiction = "OH"
+	JurisdictionOK   Jurisdiction = "OK"
+	JurisdictionOR   Jurisdiction = "OR"
+	JurisdictionPA   Jurisdiction = "PA"
+	JurisdictionPR   Jurisdiction = "PR" // Puerto Rico
+	JurisdictionRI   Jurisdiction = "RI"
+	JurisdictionSC   Jurisdiction = "SC"
+	JurisdictionSD   Jurisdiction = "SD"
+	JurisdictionTN   Jurisdiction = "TN"
+	JurisdictionTX   Jurisdiction = "TX"
+	JurisdictionUT   Jurisdiction = "UT"
+	JurisdictionVA   Jurisdiction = "VA"
+	JurisdictionVI   Jurisdiction = "VI" // U.S. Virgin Islands
+	JurisdictionVT   Jurisdiction = "VT"
+	JurisdictionWA   Jurisdiction = "WA"
+	JurisdictionWI   Jurisdiction = "WI"
+	JurisdictionWVPN Jurisdiction = "WVPN" // West Virginia State Board of Examiners for Licensed Practical Nurses
+	JurisdictionWVRN Jurisdiction = "WVRN" // West Virginia Board of Examiners for Registered Professional Nurses
+	JurisdictionWY   Jurisdiction = "WY"
+)
+
+// JurisdictionInfo describes a state board of nursing.
+type JurisdictionInfo struct {
+	Abbreviation Jurisdiction
+	Description  string // Description as returned in the Jurisdiction fields.
+	State        string // Two letter abbreviation of the state or territory the board belongs to.
+	Compact      bool   // Whether the board participates in the Nurse Licensure Compact (NLC), i.e. issues and honors multistate licenses.
+}
+
+// jurisdictions is the appendix table. Compact follows the NLC map published by NCSBN at
+// https://www.nlc.gov/ as of mid-2025, and is true only for jurisdictions that have implemented the compact.
+// Connecticut and Massachusetts enacted the NLC in 2024 but had not implemented it, so they don't issue or
+// honor multistate licenses yet and are false. Membership changes as states pass and implement the compact:
+// check the NLC map, or a nurse's CompactStatus in Nurse Lookup results, when it matters.
+var jurisdictions = map[Jurisdiction]JurisdictionInfo{
+	JurisdictionAK:   {JurisdictionAK, "Alaska", "AK", false},
+	JurisdictionAL:   {JurisdictionAL, "Alabama", "AL", true},
+	JurisdictionAR:   {JurisdictionAR, "Arkansas", "AR", true},
+	JurisdictionAS:   {JurisdictionAS, "American Samoa", "AS", false},
+	JurisdictionAZ:   {JurisdictionAZ, "Arizona", "AZ", true},
+	JurisdictionCARN: {JurisdictionCARN, "California-RN", "CA", false},
+	JurisdictionCAVN: {JurisdictionCAVN, "California-VN", "CA", false},
+	JurisdictionCO:   {JurisdictionCO, "Colorado", "CO", true},
+	JurisdictionCT:   {JurisdictionCT, "Connecticut", "CT", false},
+	JurisdictionDC:   {JurisdictionDC, "District of Columbia", "DC", false},
+	JurisdictionDE:   {JurisdictionDE, "Delaware", "DE", true},
+	JurisdictionFL:   {JurisdictionFL, "Florida", "FL", true},
+	JurisdictionGA:   {JurisdictionGA, "Georgia", "GA", true},
+	JurisdictionGU:   {JurisdictionGU, "Guam", "GU", true},
+	JurisdictionHI:   {JurisdictionHI, "Hawaii", "HI", false},
+	JurisdictionIA:   {JurisdictionIA, "Iowa", "IA", true},
+	JurisdictionID:   {JurisdictionID, "Idaho", "ID", true},
+	JurisdictionIL:   {JurisdictionIL, "Illinois", "IL", false},
+	JurisdictionIN:   {JurisdictionIN, "Indiana", "IN", true},
+	JurisdictionKS:   {JurisdictionKS, "Kansas", "KS", true},
+	JurisdictionKY:   {JurisdictionKY, "Kentucky", "KY", true},
+	JurisdictionLAPN: {JurisdictionLAPN, "Louisiana-PN", "LA", false},
+	JurisdictionLARN: {JurisdictionLARN, "Louisiana-RN", "LA", true},
+	JurisdictionMA:   {JurisdictionMA, "Massachusetts", "MA", false},
+	JurisdictionMD:   {JurisdictionMD, "Maryland", "MD", true},
+	JurisdictionME:   {JurisdictionME, "Maine", "ME", true},
+	JurisdictionMI:   {JurisdictionMI, "Michigan", "MI", false},
+	JurisdictionMN:   {JurisdictionMN, "Minnesota", "MN", false},
+	JurisdictionMO:   {JurisdictionMO, "Missouri", "MO", true},
+	JurisdictionMP:   {JurisdictionMP, "Northern Mariana Islands", "MP", false},
+	JurisdictionMS:   {JurisdictionMS, "Mississippi", "MS", true},
+	JurisdictionMT:   {JurisdictionMT, "Montana", "MT", true},
+	JurisdictionNC:   {JurisdictionNC, "North Carolina", "NC", true},
+	JurisdictionND:   {JurisdictionND, "North Dakota", "ND", true},
+	JurisdictionNE:   {JurisdictionNE, "Nebraska", "NE", true},
+	JurisdictionNH:   {JurisdictionNH, "New Hampshire", "NH", true},
+	JurisdictionNJ:   {JurisdictionNJ, "New Jersey", "NJ", true},
+	JurisdictionNM:   {JurisdictionNM, "New Mexico", "NM", true},
+	JurisdictionNV:   {JurisdictionNV, "Nevada", "NV", false},
+	JurisdictionNY:   {JurisdictionNY, "New York", "NY", false},
+	JurisdictionOH:   {JurisdictionOH, "Ohio", "OH", true},
+	JurisdictionOK:   {JurisdictionOK, "Oklahoma", "OK", true},
+	JurisdictionOR:   {JurisdictionOR, "Oregon", "OR", false},
+	JurisdictionPA:   {JurisdictionPA, "Pennsylvania", "PA", true},
+	JurisdictionPR:   {JurisdictionPR, "Puerto Rico", "PR", false},
+	JurisdictionRI:   {JurisdictionRI, "Rhode Island", "RI", false},
+	JurisdictionSC:   {JurisdictionSC, "South Carolina", "SC", true},
+	JurisdictionSD:   {JurisdictionSD, "South Dakota", "SD", true},
+	JurisdictionTN:   {JurisdictionTN, "Tennessee", "TN", true},
+	JurisdictionTX:   {JurisdictionTX, "Texas", "TX", true},
+	JurisdictionUT:   {JurisdictionUT, "Utah", "UT", true},
+	JurisdictionVA:   {JurisdictionVA, "Virginia", "VA", true},
+	JurisdictionVI:   {JurisdictionVI, "Virgin Islands", "VI", true},
+	JurisdictionVT:   {JurisdictionVT, "Vermont", "VT", true},
+	JurisdictionWA:   {JurisdictionWA, "Washington", "WA", true},
+	JurisdictionWI:   {JurisdictionWI, "Wisconsin", "WI", true},
+	JurisdictionWVPN: {JurisdictionWVPN, "West Virginia-PN", "WV", true},
+	JurisdictionWVRN: {JurisdictionWVRN, "West Virginia-RN", "WV", true},
+	JurisdictionWY:   {JurisdictionWY, "Wyoming", "WY", true},
+}
+
+// ParseJurisdiction parses a jurisdiction abbreviation, ignoring case and accepting the separated spellings
+// of split boards, such as "CA-RN" or "ca rn" for JurisdictionCARN.
+func ParseJurisdiction(s string) (Jurisdiction, error) {
+	j := Jurisdiction(strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToUpper(s)))
+	if !j.Valid() {
+		return "", fmt.Errorf("nursys: unknown jurisdiction %q", s)
+	}
+	return j, nil
+}
+
+// Jurisdictions returns the appendix table, ordered by abbreviation.
+func Jurisdictions() []JurisdictionInfo {
+	infos := make([]JurisdictionInfo, 0, len(jurisdictions))
+	for _, info := range jurisdictions {
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, k int) bool { return infos[i].Abbreviation < infos[k].Abbreviation })
+	return infos
+}
+
+// Valid reports whether j is a jurisdiction listed in the appendix.
+func (j Jurisdiction) Valid() bool {
+	_, ok := jurisdictions[j]
+	return ok
+}
+
+// Info returns the appendix entry for j, and whether j is valid.
+func (j Jurisdiction) Info() (JurisdictionInfo, bool) {
+	info, ok := jurisdictions[j]
+	return info, ok
+}
+
+// Description returns the description of the board, or the empty string for unknown jurisdictions.
+func (j Jurisdiction) Description() string {
+	return jurisdictions[j].Description
+}
+
+// State returns the two letter abbreviation of the state or territory of the board, e.g. "CA" for CA-RN.
+func (j Jurisdiction) State() string {
+	return jurisdictions[j].State
+}
+
+// Compact reports whether the board participates in the Nurse Licensure Compact.
+func (j Jurisdiction) Compact() bool {
+	return jurisdictions[j].Compact
+}
@@ -0,0 +1,55 @@
+package nursys_test
+
+import (
+	"testing"
+
+	"github.com/connectRN/go-nursys"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseJurisdiction(t *testing.T) {
+	for input, want := range map[string]nursys.Jurisdiction{
+		"NY":     nursys.JurisdictionNY,
+		" tx ":   nursys.JurisdictionTX,
+		"CA-RN":  nursys.JurisdictionCARN,
+		"ca vn":  nursys.JurisdictionCAVN,
+		"WV_PN":  nursys.JurisdictionWVPN,
+		"LARN":   nursys.JurisdictionLARN,
+		"vi":     nursys.JurisdictionVI,
+		"Guam":   "",
+		"CA":     "", // California has no single board
+		"XX-RN ": "",
+	} {
+		j, err := nursys.ParseJurisdiction(input)
+		if want == "" {
+			assert.Error(t, err, input)
+		} else {
+			assert.NoError(t, err, input)
+			assert.Equal(t, want, j, input)
+		}
+	}
+}
+
+func Test_JurisdictionInfo(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("California-VN", nursys.JurisdictionCAVN.Description())
+	assert.Equal("CA", nursys.JurisdictionCAVN.State())
+	assert.True(nursys.JurisdictionTX.Compact())
+	assert.False(nursys.JurisdictionNY.Compact())
+	assert.True(nursys.JurisdictionLARN.Compact())
+	assert.False(nursys.JurisdictionLAPN.Compact())
+	assert.False(nursys.JurisdictionCT.Compact()) // Enacted, not implemented
+	assert.False(nursys.Jurisdiction("XX").Valid())
+	assert.Equal("", nursys.Jurisdiction("XX").Description())
+
+	all := nursys.Jurisdictions()
+	assert.Len(all, 59)
+	for i, info := range all {
+		assert.LessOrEqual(len(info.Abbreviation), 4, info.Abbreviation) // Field length in the spec
+		if i > 0 {
+			assert.Less(all[i-1].Abbreviation, info.Abbreviation)
+		}
+	}
+}
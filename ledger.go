@@ -0,0 +1,199 @@
+package nursys
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Ledger is a local, file-backed record of ManageNurseList submissions and of the nurses they left
+// enrolled on the institution’s nurse list. Nursys does not offer a way to list enrolled nurses, so the
+// ledger is the client’s memory of what it has enrolled. Its EnrolledRequests are suitable as the
+// enrolled set passed to Reconcile.
+//
+// A Ledger is safe for concurrent use within a process. It should not be shared between processes.
+type Ledger struct {
+	mu   sync.Mutex
+	path string
+	data ledgerData
+}
+
+type ledgerData struct {
+	Submissions []LedgerSubmission     `json:"Submissions"`
+	Enrolled    map[string]LedgerEntry `json:"Enrolled"` // Keyed by ManageNurseListRequest.LicenseKey
+}
+
+// LedgerSubmission is the recorded outcome of one ManageNurseList transaction.
+type LedgerSubmission struct {
+	TransactionID   string                   `json:"TransactionId"`
+	TransactionDate Time                     `json:"TransactionDate"`
+	RecordedAt      time.Time                `json:"RecordedAt"`
+	Responses       []MangeNurseListResponse `json:"Responses"`
+}
+
+// LedgerEntry describes a nurse currently enrolled according to the ledger.
+type LedgerEntry struct {
+	Request       ManageNurseListRequest `json:"Request"`       // Settings from the most recent successful add or update.
+	EnrolledSince time.Time              `json:"EnrolledSince"` // Date of the transaction that first enrolled the nurse.
+	UpdatedAt     time.Time              `json:"UpdatedAt"`     // Date of the transaction that last added or updated the nurse.
+	TransactionID string                 `json:"TransactionId"` // Transaction that last added or updated the nurse.
+}
+
+// OpenLedger loads the ledger stored at path. A missing file is treated as an empty ledger and is created on
+// the first Record.
+func OpenLedger(path string) (*Ledger, error) {
+	l := &Ledger{path: path, data: ledgerData{Enrolled: map[string]LedgerEntry{}}}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return l, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &l.data); err != nil {
+		return nil, fmt.Errorf("nursys: reading ledger %s: %w", path, err)
+	}
+	if l.data.Enrolled == nil {
+		l.data.Enrolled = map[string]LedgerEntry{}
+	}
+	return l, nil
+}
+
+// Record stores the outcome of a completed ManageNurseList transaction and updates the enrolled set:
+// successful adds enroll or update a nurse, successful removes drop it. Failed rows are kept in the
+// submission history but do not change the enrolled set.
+func (l *Ledger) Record(result ManageNurseListRetrieveResponseMessage) error {
+	if !result.ProcessingCompleteFlag {
+		return fmt.Errorf("nursys: transaction %s has not finished processing", result.Transaction.TransactionID)
+	}
+	now := time.Now()
+	txDate := time.Time(result.Transaction.TransactionDate)
+	if txDate.IsZero() {
+		txDate = now
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// Changes are made to a copy, kept only once saved, so that a failed save leaves the ledger as it was.
+	data := ledgerData{
+		Submissions: slices.Clone(l.data.Submissions),
+		Enrolled:    maps.Clone(l.data.Enrolled),
+	}
+	data.Submissions = append(data.Submissions, LedgerSubmission{
+		TransactionID:   result.Transaction.TransactionID,
+		TransactionDate: result.Transaction.TransactionDate,
+		RecordedAt:      now,
+		Responses:       result.ManageNurseListResponses,
+	})
+	for _, resp := range result.ManageNurseListResponses {
+		if !resp.SuccessFlag {
+			continue
+		}
+		req := resp.ManageNurseListRequest
+		key := req.LicenseKey()
+		switch req.SubmissionActionCode {
+		case ActionCodeAdd:
+			entry, ok := data.Enrolled[key]
+			if !ok {
+				entry.EnrolledSince = txDate
+			}
+			entry.Request = req
+			entry.UpdatedAt = txDate
+			entry.TransactionID = result.Transaction.TransactionID
+			data.Enrolled[key] = entry
+		case ActionCodeRemove:
+			delete(data.Enrolled, key)
+		}
+	}
+	if err := l.save(data); err != nil {
+		return err
+	}
+	l.data = data
+	return nil
+}
+
+// Lookup returns the ledger entry for the license with the given ManageNurseListRequest.LicenseKey,
+// and whether it is currently enrolled.
+func (l *Ledger) Lookup(licenseKey string) (LedgerEntry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entry, ok := l.data.Enrolled[licenseKey]
+	return entry, ok
+}
+
+// Enrolled returns all currently enrolled entries, ordered by license key.
+func (l *Ledger) Enrolled() []LedgerEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entries := make([]LedgerEntry, 0, len(l.data.Enrolled))
+	for _, key := range sortedKeys(l.data.Enrolled) {
+		entries = append(entries, l.data.Enrolled[key])
+	}
+	return entries
+}
+
+// EnrolledRequests returns the settings of all currently enrolled nurses, ordered by license key.
+func (l *Ledger) EnrolledRequests() []ManageNurseListRequest {
+	entries := l.Enrolled()
+	requests := make([]ManageNurseListRequest, len(entries))
+	for i, e := range entries {
+		requests[i] = e.Request
+	}
+	return requests
+}
+
+// Submissions returns the recorded submission history, oldest first.
+func (l *Ledger) Submissions() []LedgerSubmission {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	submissions := make([]LedgerSubmission, len(l.data.Submissions))
+	copy(submissions, l.data.Submissions)
+	sort.SliceStable(submissions, func(i, j int) bool {
+		return submissions[i].RecordedAt.Before(submissions[j].RecordedAt)
+	})
+	return submissions
+}
+
+// Export writes the currently enrolled entries to w as a JSON array.
+func (l *Ledger) Export(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(l.Enrolled())
+}
+
+// save writes data to the ledger file. The caller must hold l.mu.
+func (l *Ledger) save(data ledgerData) error {
+	b, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(l.path, b)
+}
+
+// writeFileAtomic writes data to a new temporary file in the directory of path and renames it over path,
+// so that a crash never leaves a partially written file behind.
+func writeFileAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
@@ -0,0 +1,118 @@
+package nursys_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/connectRN/go-nursys"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Ledger(t *testing.T) {
+	assert := assert.New(t)
+	path := filepath.Join(t.TempDir(), "ledger.json")
+
+	first, second := testNurse("1"), testNurse("2")
+	first.SubmissionActionCode = nursys.ActionCodeAdd
+	second.SubmissionActionCode = nursys.ActionCodeAdd
+	day1 := time.Date(2024, 1, 4, 10, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+
+	ledger, err := nursys.OpenLedger(path)
+	require.NoError(t, err)
+	require.NoError(t, ledger.Record(nursys.ManageNurseListRetrieveResponseMessage{
+		ProcessingCompleteFlag: true,
+		Transaction:            nursys.Transaction{TransactionID: "tx1", TransactionDate: nursys.Time(day1)},
+		ManageNurseListResponses: []nursys.MangeNurseListResponse{
+			{SuccessFlag: true, ManageNurseListRequest: first},
+			{SuccessFlag: false, ManageNurseListRequest: second, Errors: []nursys.TransactionError{{ErrorID: 1, ErrorMessage: "bad"}}},
+		},
+	}))
+
+	// Update the first nurse, enroll the second.
+	updated := first
+	updated.Email = "nurse@example.com"
+	require.NoError(t, ledger.Record(nursys.ManageNurseListRetrieveResponseMessage{
+		ProcessingCompleteFlag: true,
+		Transaction:            nursys.Transaction{TransactionID: "tx2", TransactionDate: nursys.Time(day2)},
+		ManageNurseListResponses: []nursys.MangeNurseListResponse{
+			{SuccessFlag: true, ManageNurseListRequest: updated},
+			{SuccessFlag: true, ManageNurseListRequest: second},
+		},
+	}))
+
+	// Reopen from disk
+	ledger, err = nursys.OpenLedger(path)
+	require.NoError(t, err)
+
+	entry, ok := ledger.Lookup(first.LicenseKey())
+	require.True(t, ok)
+	assert.Equal(updated, entry.Request)
+	assert.True(day1.Equal(entry.EnrolledSince))
+	assert.True(day2.Equal(entry.UpdatedAt))
+	assert.Equal("tx2", entry.TransactionID)
+	assert.Equal([]nursys.ManageNurseListRequest{updated, second}, ledger.EnrolledRequests())
+	assert.Len(ledger.Submissions(), 2)
+
+	removed := second
+	removed.SubmissionActionCode = nursys.ActionCodeRemove
+	require.NoError(t, ledger.Record(nursys.ManageNurseListRetrieveResponseMessage{
+		ProcessingCompleteFlag:   true,
+		Transaction:              nursys.Transaction{TransactionID: "tx3"},
+		ManageNurseListResponses: []nursys.MangeNurseListResponse{{SuccessFlag: true, ManageNurseListRequest: removed}},
+	}))
+	_, ok = ledger.Lookup(second.LicenseKey())
+	assert.False(ok)
+
+	assert.Error(ledger.Record(nursys.ManageNurseListRetrieveResponseMessage{ProcessingCompleteFlag: false}))
+}
+
+func Test_Ledger_SaveFailure(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ledger.json")
+
+	record := func(ledger *nursys.Ledger, txID, number string) error {
+		nurse := testNurse(number)
+		nurse.SubmissionActionCode = nursys.ActionCodeAdd
+		return ledger.Record(nursys.ManageNurseListRetrieveResponseMessage{
+			ProcessingCompleteFlag:   true,
+			Transaction:              nursys.Transaction{TransactionID: txID},
+			ManageNurseListResponses: []nursys.MangeNurseListResponse{{SuccessFlag: true, ManageNurseListRequest: nurse}},
+		})
+	}
+
+	ledger, err := nursys.OpenLedger(path)
+	require.NoError(t, err)
+	require.NoError(t, record(ledger, "tx1", "1"))
+
+	// A directory in place of the ledger file makes the save fail.
+	require.NoError(t, os.Rename(path, path+".bak"))
+	require.NoError(t, os.Mkdir(path, 0o700))
+	assert.Error(record(ledger, "tx2", "2"))
+	assert.Len(ledger.Enrolled(), 1)
+	assert.Len(ledger.Submissions(), 1)
+
+	require.NoError(t, os.Remove(path))
+	require.NoError(t, record(ledger, "tx3", "3"))
+	reopened, err := nursys.OpenLedger(path)
+	require.NoError(t, err)
+	var txIDs []string
+	for _, s := range reopened.Submissions() {
+		txIDs = append(txIDs, s.TransactionID)
+	}
+	assert.Equal([]string{"tx1", "tx3"}, txIDs)
+
+	// No temporary files are left behind.
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	assert.Equal([]string{"ledger.json", "ledger.json.bak"}, names)
+}
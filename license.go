@@ -0,0 +1,67 @@
+package nursys
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OriginalDate returns the parsed LicenseOriginalDate, and false if it is empty or not in a recognized format.
+func (l NurseLookupLicense) OriginalDate() (time.Time, bool) {
+	return parseDate(l.LicenseOriginalDate)
+}
+
+// ExpirationDate returns the parsed LicenseExpirationDate, and false if it is empty or not in a recognized format.
+func (l NurseLookupLicense) ExpirationDate() (time.Time, bool) {
+	return parseDate(l.LicenseExpirationDate)
+}
+
+// IsExpired reports whether the license expired before the day of now. A license is valid through its
+// expiration date. Licenses without a recognized expiration date are not considered expired.
+func (l NurseLookupLicense) IsExpired(now time.Time) bool {
+	exp, ok := l.ExpirationDate()
+	if !ok {
+		return false
+	}
+	return daysBetween(now, exp) < 0
+}
+
+// IsActive reports whether Nursys reports the license as active. The Active field is used when present,
+// otherwise LicenseStatus.
+func (l NurseLookupLicense) IsActive() bool {
+	switch strings.ToLower(strings.TrimSpace(l.Active)) {
+	case "yes", "y", "true", "active":
+		return true
+	case "":
+		return strings.HasPrefix(strings.ToLower(strings.TrimSpace(l.LicenseStatus)), "active")
+	}
+	return false
+}
+
+// IsMultistate reports whether the license is a multistate license under the Nurse Licensure Compact,
+// according to CompactStatus: "Multistate", ignoring case, an optional hyphen and a trailing " License".
+// Other statuses, such as "Single State" or "Non-Multistate", are not multistate.
+func (l NurseLookupLicense) IsMultistate() bool {
+	status := strings.ToLower(strings.TrimSpace(l.CompactStatus))
+	status = strings.TrimSuffix(strings.ReplaceAll(status, "-", ""), " license")
+	return status == "multistate"
+}
+
+// parseDate parses the date formats Nursys returns in string date fields: the formats accepted by Time,
+// plain dates (2006-01-02) and US dates (01/02/2006).
+func parseDate(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, false
+	}
+	var t Time
+	if err := t.UnmarshalJSON([]byte(strconv.Quote(s))); err == nil {
+		return time.Time(t), true
+	}
+	for _, layout := range []string{time.DateOnly, "01/02/2006", "1/2/2006"} {
+		if tm, err := time.Parse(layout, s); err == nil {
+			return tm, true
+		}
+	}
+	return time.Time{}, false
+}
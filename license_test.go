@@ -0,0 +1,39 @@
+package nursys_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/connectRN/go-nursys"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NurseLookupLicense_Accessors(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, s := range []string{"2025-06-30T00:00:00", "2025-06-30", "06/30/2025", "6/30/2025", "2025-06-30T00:00:00-05:00"} {
+		exp, ok := nursys.NurseLookupLicense{LicenseExpirationDate: s}.ExpirationDate()
+		assert.True(ok, s)
+		y, m, d := exp.Date()
+		assert.Equal([]int{2025, 6, 30}, []int{y, int(m), d}, s)
+	}
+	_, ok := nursys.NurseLookupLicense{LicenseExpirationDate: "soon"}.ExpirationDate()
+	assert.False(ok)
+	_, ok = nursys.NurseLookupLicense{}.OriginalDate()
+	assert.False(ok)
+
+	license := nursys.NurseLookupLicense{LicenseExpirationDate: "06/30/2025", Active: "Yes", CompactStatus: "Multistate"}
+	assert.False(license.IsExpired(time.Date(2025, 6, 30, 23, 0, 0, 0, time.UTC)))
+	assert.True(license.IsExpired(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(license.IsActive())
+	assert.True(license.IsMultistate())
+
+	assert.False(nursys.NurseLookupLicense{Active: "No", LicenseStatus: "Active"}.IsActive())
+	assert.True(nursys.NurseLookupLicense{LicenseStatus: "Active - Probation"}.IsActive())
+	assert.False(nursys.NurseLookupLicense{LicenseStatus: "Inactive"}.IsActive())
+	assert.True(nursys.NurseLookupLicense{CompactStatus: " Multi-State License"}.IsMultistate())
+	for _, status := range []string{"Single State", "Non-Multistate", "Not Multistate", "Nonmultistate", ""} {
+		assert.False(nursys.NurseLookupLicense{CompactStatus: status}.IsMultistate(), status)
+	}
+}
@@ -0,0 +1,121 @@
+package nursys
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// LicenseType is a nursing license type code. See the LicenseType constants for valid values.
+//
+// Values that are not in the appendix are passed through when marshaling and unmarshaling JSON, so that a
+// license type added to Nursys does not break decoding of entire responses. Clients created with
+// WithStrictLicenseTypes reject them, and other callers can check messages with ValidateLicenseTypes.
+type LicenseType string
+
+// ErrUnknownLicenseType is returned, wrapped, for license types that are not listed in the appendix.
+var ErrUnknownLicenseType = errors.New("nursys: unknown license type")
+
+// WithStrictLicenseTypes makes the client check the license types of every request before sending it and
+// of every response after decoding it, with ValidateLicenseTypes. A request with an unknown license type
+// is not sent, and a response with one is returned along with an error wrapping ErrUnknownLicenseType.
+func WithStrictLicenseTypes() ClientOption {
+	return func(c *nsHTTPClient) {
+		c.strict = true
+	}
+}
+
+// ParseLicenseType parses a license type code, ignoring case and surrounding space.
+func ParseLicenseType(s string) (LicenseType, error) {
+	lt := LicenseType(strings.ToUpper(strings.TrimSpace(s)))
+	if !lt.Valid() {
+		return "", fmt.Errorf("nursys: unknown license type %q", s)
+	}
+	return lt, nil
+}
+
+// Valid reports whether lt is a license type listed in the appendix.
+func (lt LicenseType) Valid() bool {
+	switch lt {
+	case LicenseTypeRN, LicenseTypePN, LicenseTypeCNM, LicenseTypeCRNA, LicenseTypeCNS, LicenseTypeCNP:
+		return true
+	}
+	return false
+}
+
+// IsAPRN reports whether lt is an advanced practice registered nurse (APRN) license type.
+func (lt LicenseType) IsAPRN() bool {
+	switch lt {
+	case LicenseTypeCNM, LicenseTypeCRNA, LicenseTypeCNS, LicenseTypeCNP:
+		return true
+	}
+	return false
+}
+
+// Family returns the license type whose authorization to practice covers lt: LicenseTypePN for practical
+// nurses, and LicenseTypeRN for registered nurses and APRNs, which practice on an RN license. It returns
+// the empty string for unknown license types.
+//
+// The family selects between NurseLookupResponse.NurseLookupRNAuthorizationsToPractice and
+// NurseLookupPNAuthorizationsToPractice.
+func (lt LicenseType) Family() LicenseType {
+	switch {
+	case lt == LicenseTypePN:
+		return LicenseTypePN
+	case lt == LicenseTypeRN, lt.IsAPRN():
+		return LicenseTypeRN
+	}
+	return ""
+}
+
+// Validate returns an error if lt is neither empty nor a license type listed in the appendix.
+func (lt LicenseType) Validate() error {
+	if lt != "" && !lt.Valid() {
+		return fmt.Errorf("%w %q", ErrUnknownLicenseType, string(lt))
+	}
+	return nil
+}
+
+// ValidateLicenseTypes checks every LicenseType in v, such as a request or response message, including
+// those of nested structs, slices and maps, with Validate and returns the first error.
+func ValidateLicenseTypes(v interface{}) error {
+	return validateLicenseTypes(reflect.ValueOf(v))
+}
+
+var licenseTypeType = reflect.TypeOf(LicenseType(""))
+
+func validateLicenseTypes(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.String:
+		if v.Type() == licenseTypeType {
+			return LicenseType(v.String()).Validate()
+		}
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			return validateLicenseTypes(v.Elem())
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				if err := validateLicenseTypes(v.Field(i)); err != nil {
+					return err
+				}
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := validateLicenseTypes(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := validateLicenseTypes(iter.Value()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
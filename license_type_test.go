@@ -0,0 +1,86 @@
+package nursys_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/connectRN/go-nursys"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LicenseType(t *testing.T) {
+	assert := assert.New(t)
+
+	lt, err := nursys.ParseLicenseType(" crna")
+	require.NoError(t, err)
+	assert.Equal(nursys.LicenseTypeCRNA, lt)
+	assert.True(lt.IsAPRN())
+	assert.Equal(nursys.LicenseTypeRN, lt.Family())
+	assert.False(nursys.LicenseTypeRN.IsAPRN())
+	assert.Equal(nursys.LicenseTypePN, nursys.LicenseTypePN.Family())
+	assert.Equal(nursys.LicenseType(""), nursys.LicenseType("LPN").Family())
+
+	_, err = nursys.ParseLicenseType("LPN")
+	assert.Error(err)
+}
+
+func Test_LicenseType_JSON(t *testing.T) {
+	assert := assert.New(t)
+
+	var req nursys.NurseLookupRequest
+	require.NoError(t, json.Unmarshal([]byte(`{"LicenseType": "APRN"}`), &req))
+	assert.Equal(nursys.LicenseType("APRN"), req.LicenseType) // Passed through
+	b, err := json.Marshal(nursys.NurseLookupRequest{NcsbnID: "123"})
+	require.NoError(t, err)
+	assert.JSONEq(`{"NcsbnId": "123"}`, string(b)) // Empty is omitted
+}
+
+func Test_ValidateLicenseTypes(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(nursys.LicenseType("").Validate())
+	assert.NoError(nursys.LicenseTypeCNP.Validate())
+	assert.EqualError(nursys.LicenseType("APRN").Validate(), `nursys: unknown license type "APRN"`)
+
+	msg := nursys.NurseLookupSubmitRequestMessage{NurseLookupRequests: []nursys.NurseLookupRequest{
+		{LicenseType: nursys.LicenseTypeRN},
+		{NcsbnID: "123"},
+	}}
+	assert.NoError(nursys.ValidateLicenseTypes(msg))
+	msg.NurseLookupRequests = append(msg.NurseLookupRequests, nursys.NurseLookupRequest{LicenseType: "APRN"})
+	assert.EqualError(nursys.ValidateLicenseTypes(&msg), `nursys: unknown license type "APRN"`)
+	assert.Error(nursys.ValidateLicenseTypes(map[string]nursys.LicenseType{"a": "LPN"}))
+}
+
+func Test_WithStrictLicenseTypes(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests++
+		rw.Write([]byte(`{"ProcessingCompleteFlag": true, "Transaction": {"TransactionSuccessFlag": true},
+			"NurseLookupResponses": [{"NurseLookupLicenses": [{"LicenseType": "APRN"}]}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	// Without the option unknown values are passed through.
+	resp, err := nursys.New(server.URL, "acme", "1234!").GetNurseLookupResult(ctx, "tx")
+	require.NoError(t, err)
+	assert.Equal(nursys.LicenseType("APRN"), resp.NurseLookupResponses[0].NurseLookupLicenses[0].LicenseType)
+
+	strict := nursys.New(server.URL, "acme", "1234!", nursys.WithStrictLicenseTypes())
+	_, err = strict.GetNurseLookupResult(ctx, "tx")
+	assert.ErrorIs(err, nursys.ErrUnknownLicenseType)
+	assert.ErrorContains(err, "GetNurseLookupResult response")
+
+	// A request with an unknown license type is not sent.
+	_, err = strict.NurseLookup(ctx, nursys.NurseLookupSubmitRequestMessage{NurseLookupRequests: []nursys.NurseLookupRequest{{LicenseType: "APRN"}}})
+	assert.ErrorIs(err, nursys.ErrUnknownLicenseType)
+	assert.Equal(2, requests)
+}
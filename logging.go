@@ -0,0 +1,142 @@
+package nursys
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// redacted replaces the values of PII and credential fields in logged request and response bodies.
+const redacted = "[REDACTED]"
+
+// redactedFields are the JSON fields, compared case-insensitively, whose values are never logged.
+var redactedFields = map[string]bool{
+	"lastfourssn": true,
+	"birthyear":   true,
+	"address1":    true,
+	"address2":    true,
+	"city":        true,
+	"state":       true,
+	"zip":         true,
+	"email":       true,
+	"newpassword": true,
+	"password":    true,
+}
+
+// WithLogger makes the client log every request to logger. Each request is logged at info level with its
+// method, endpoint, duration, HTTP status and the TransactionId and TransactionSuccessFlag of the response,
+// and at error level if it fails. Request and response bodies are logged at debug level only, with PII
+// fields (LastFourSSN, BirthYear, address fields including City and State, Email) and passwords redacted.
+// The body of an error response is likewise logged only at debug level. A nil logger disables logging.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *nsHTTPClient) {
+		c.logger = logger
+	}
+}
+
+// logRequest logs the outcome of a request. status is 0 if no response was received.
+func (cfg *nsHTTPClient) logRequest(ctx context.Context, method, endpoint string, start time.Time, status int, target interface{}, err error) {
+	if cfg.logger == nil {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("endpoint", endpoint),
+		slog.Duration("duration", time.Since(start)),
+		slog.Int("status", status),
+	}
+	if tx, ok := transactionOf(target); ok && err == nil {
+		attrs = append(attrs, slog.String("transactionId", tx.TransactionID), slog.Bool("transactionSuccessFlag", tx.TransactionSuccessFlag))
+	}
+	if err != nil {
+		// The message of a StatusError includes the response body, which may contain PII. do logs the body
+		// separately, redacted and at debug level.
+		msg := err.Error()
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) {
+			msg = fmt.Sprintf("nursys: request returned %d", statusErr.StatusCode)
+		}
+		attrs = append(attrs, slog.String("error", msg))
+		cfg.logger.LogAttrs(ctx, slog.LevelError, "nursys request failed", attrs...)
+		return
+	}
+	cfg.logger.LogAttrs(ctx, slog.LevelInfo, "nursys request", attrs...)
+}
+
+// logBody logs a request or response body at debug level, with sensitive fields redacted.
+func (cfg *nsHTTPClient) logBody(ctx context.Context, msg, method, endpoint string, body []byte) {
+	if !cfg.debugEnabled(ctx) {
+		return
+	}
+	cfg.logger.LogAttrs(ctx, slog.LevelDebug, msg,
+		slog.String("method", method),
+		slog.String("endpoint", endpoint),
+		slog.String("username", cfg.username),
+		slog.String("password", redacted),
+		slog.String("body", redactJSON(body)),
+	)
+}
+
+func (cfg *nsHTTPClient) debugEnabled(ctx context.Context) bool {
+	return cfg.logger != nil && cfg.logger.Enabled(ctx, slog.LevelDebug)
+}
+
+// transactionOf returns the Transaction of a response message, which all have a Transaction field.
+func transactionOf(v interface{}) (Transaction, bool) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return Transaction{}, false
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return Transaction{}, false
+	}
+	f := rv.FieldByName("Transaction")
+	if !f.IsValid() {
+		return Transaction{}, false
+	}
+	tx, ok := f.Interface().(Transaction)
+	return tx, ok
+}
+
+// redactJSON returns body with the values of redactedFields replaced. Bodies that are not JSON are
+// replaced entirely, since they cannot be inspected.
+func redactJSON(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return redacted
+	}
+	b, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return redacted
+	}
+	return string(b)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			if redactedFields[strings.ToLower(k)] {
+				v[k] = redacted
+			} else {
+				v[k] = redactValue(field)
+			}
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = redactValue(e)
+		}
+	}
+	return v
+}
@@ -0,0 +1,103 @@
+package nursys_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/connectRN/go-nursys"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithLogger(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write(submitResponseJSON)
+	}))
+	t.Cleanup(server.Close)
+
+	logs := func(level slog.Level) []map[string]interface{} {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: level}))
+		client := nursys.New(server.URL, "acme", "secret!", nursys.WithLogger(logger))
+
+		req := testNurse("1")
+		req.Email = "nurse@example.com"
+		_, err := client.ManageNurseList(context.Background(), nursys.ManageNurseListSubmitRequestMessage{
+			ManageNurseListRequests: []nursys.ManageNurseListRequest{req},
+		})
+		require.NoError(t, err)
+
+		for _, secret := range []string{"secret!", "1234", "1980", "1 Main St", "12207", "nurse@example.com"} {
+			assert.NotContains(buf.String(), secret)
+		}
+		var records []map[string]interface{}
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			var record map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(line), &record))
+			records = append(records, record)
+		}
+		return records
+	}
+
+	records := logs(slog.LevelInfo)
+	require.Len(t, records, 1)
+	assert.Equal("nursys request", records[0]["msg"])
+	assert.Equal("POST", records[0]["method"])
+	assert.Equal("/managenurselist", records[0]["endpoint"])
+	assert.EqualValues(200, records[0]["status"])
+	assert.NotEmpty(records[0]["transactionId"])
+	assert.Contains(records[0], "transactionSuccessFlag")
+	assert.Contains(records[0], "duration")
+
+	records = logs(slog.LevelDebug)
+	require.Len(t, records, 3)
+	assert.Equal("nursys request body", records[0]["msg"])
+	assert.Contains(records[0]["body"], `"City":"[REDACTED]"`)
+	assert.Contains(records[0]["body"], `"LastFourSSN":"[REDACTED]"`)
+	assert.Equal("[REDACTED]", records[0]["password"])
+	assert.Equal("nursys response body", records[1]["msg"])
+	assert.Equal("nursys request", records[2]["msg"])
+}
+
+func Test_WithLogger_StatusError(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusInternalServerError)
+		rw.Write([]byte(`{"Message":"invalid nurse","Email":"nurse@example.com","LastFourSSN":"1234"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := nursys.New(server.URL, "acme", "secret!", nursys.WithLogger(logger))
+	_, err := client.ManageNurseList(context.Background(), nursys.ManageNurseListSubmitRequestMessage{})
+	require.Error(t, err)
+
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	require.Len(t, records, 3)
+	assert.Equal("nursys response body", records[1]["msg"])
+	assert.Equal("DEBUG", records[1]["level"])
+	assert.Contains(records[1]["body"], `"Email":"[REDACTED]"`)
+	assert.Contains(records[1]["body"], `"Message":"invalid nurse"`)
+	assert.Equal("nursys request failed", records[2]["msg"])
+	assert.Equal("nursys: request returned 500", records[2]["error"])
+	assert.EqualValues(500, records[2]["status"])
+	for _, secret := range []string{"nurse@example.com", "1234"} {
+		assert.NotContains(buf.String(), secret)
+	}
+}
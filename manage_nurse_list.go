@@ -13,7 +13,7 @@ import (
 // client calls the Manage Nurse List HTTP GET method with that TransactionId to retrieve their results.
 func (c *nsHTTPClient) ManageNurseList(ctx context.Context, request ManageNurseListSubmitRequestMessage) (ManageNurseListSubmitResponseMessage, error) {
 	var response ManageNurseListSubmitResponseMessage
-	err := c.invokeEndpoint(ctx, "POST", "/managenurselist", request, &response)
+	err := c.invoke(ctx, &Call{Endpoint: EndpointManageNurseList, Method: "POST", Path: "/managenurselist", Request: request, Response: &response})
 	return response, err
 }
 
@@ -28,25 +28,25 @@ type ManageNurseListSubmitRequestMessage struct {
 // and NCSBN ID. Different combinations of licenses will be affected. This applies to license being added,
 // updated, and removed
 type ManageNurseListRequest struct {
-	SubmissionActionCode         string `json:"SubmissionActionCode"`                   // Required 1 Submission action code
-	JurisdictionAbbreviation     string `json:"JurisdictionAbbreviation,omitempty"`     // Optional 4 State board of nursing. Please see section 3.2.2 for matching rules.
-	LicenseNumber                string `json:"LicenseNumber,omitempty"`                // Optional 15 License number. Please see section 3.2.2 for matching rules.
-	LicenseType                  string `json:"LicenseType,omitempty"`                  // Optional 4 License type. Please see section 3.2.2 for matching rules.
-	NcsbnID                      string `json:"NcsbnId,omitempty"`                      // Optional 10 NCSBN ID is the public, globally unique identifier for all nurses from participating boards of nursing. Please see section 3.2.2 for matching rules.
-	Email                        string `json:"Email,omitempty"`                        // Optional 50 E-mail address.
-	Address1                     string `json:"Address1"`                               // Required 50 Address line 1.
-	Address2                     string `json:"Address2,omitempty"`                     // Optional 50 Address line 2.
-	City                         string `json:"City"`                                   // Required 50 City.
-	State                        string `json:"State"`                                  // Required 2 State.
-	Zip                          string `json:"Zip"`                                    // Required 10 Zip code.
-	LastFourSSN                  string `json:"LastFourSSN"`                            // Required 4 Last four digits of social security number.
-	BirthYear                    string `json:"BirthYear"`                              // Required 4 Birth year.
-	HospitalPracticeSetting      string `json:"HospitalPracticeSetting,omitempty"`      // Required 2 Hospital practice setting.
-	HospitalPracticeSettingOther string `json:"HospitalPracticeSettingOther,omitempty"` // Optional 50 Hospital practice setting (other).
-	NotificationsEnabled         string `json:"NotificationsEnabled"`                   // Required 1 License and state licensure action notifications enabled.
-	RemindersEnabled             string `json:"RemindersEnabled"`                       // Required 1 License expiration reminders enabled.
-	RecordID                     string `json:"RecordId,omitempty"`                     // Optional 50 Client-provided identifier echoed back as part of the response.
-	LocationList                 string `json:"LocationList,omitempty"`                 // Optional 100 Pipe delimited list of location codes.
+	SubmissionActionCode         string       `json:"SubmissionActionCode"`                   // Required 1 Submission action code
+	JurisdictionAbbreviation     Jurisdiction `json:"JurisdictionAbbreviation,omitempty"`     // Optional 4 State board of nursing. Please see section 3.2.2 for matching rules.
+	LicenseNumber                string       `json:"LicenseNumber,omitempty"`                // Optional 15 License number. Please see section 3.2.2 for matching rules.
+	LicenseType                  LicenseType  `json:"LicenseType,omitempty"`                  // Optional 4 License type. Please see section 3.2.2 for matching rules.
+	NcsbnID                      string       `json:"NcsbnId,omitempty"`                      // Optional 10 NCSBN ID is the public, globally unique identifier for all nurses from participating boards of nursing. Please see section 3.2.2 for matching rules.
+	Email                        string       `json:"Email,omitempty"`                        // Optional 50 E-mail address.
+	Address1                     string       `json:"Address1"`                               // Required 50 Address line 1.
+	Address2                     string       `json:"Address2,omitempty"`                     // Optional 50 Address line 2.
+	City                         string       `json:"City"`                                   // Required 50 City.
+	State                        string       `json:"State"`                                  // Required 2 State.
+	Zip                          string       `json:"Zip"`                                    // Required 10 Zip code.
+	LastFourSSN                  string       `json:"LastFourSSN"`                            // Required 4 Last four digits of social security number.
+	BirthYear                    string       `json:"BirthYear"`                              // Required 4 Birth year.
+	HospitalPracticeSetting      string       `json:"HospitalPracticeSetting,omitempty"`      // Required 2 Hospital practice setting.
+	HospitalPracticeSettingOther string       `json:"HospitalPracticeSettingOther,omitempty"` // Optional 50 Hospital practice setting (other).
+	NotificationsEnabled         string       `json:"NotificationsEnabled"`                   // Required 1 License and state licensure action notifications enabled.
+	RemindersEnabled             string       `json:"RemindersEnabled"`                       // Required 1 License expiration reminders enabled.
+	RecordID                     string       `json:"RecordId,omitempty"`                     // Optional 50 Client-provided identifier echoed back as part of the response.
+	LocationList                 string       `json:"LocationList,omitempty"`                 // Optional 100 Pipe delimited list of location codes.
 }
 
 // The ManageNurseListSubmitResponseMessage models the response from the Manage Nurse List HTTP POST method.
@@ -59,7 +59,7 @@ type ManageNurseListSubmitResponseMessage struct {
 // GetManageNurseListResult retrieves the results of a ManageNurseList transaction.
 func (c *nsHTTPClient) GetManageNurseListResult(ctx context.Context, txID string) (ManageNurseListRetrieveResponseMessage, error) {
 	var response ManageNurseListRetrieveResponseMessage
-	err := c.invokeEndpoint(ctx, "GET", "/managenurselist?transactionId="+url.QueryEscape(txID), nil, &response)
+	err := c.invoke(ctx, &Call{Endpoint: EndpointGetManageNurseListResult, Method: "GET", Path: "/managenurselist?transactionId=" + url.QueryEscape(txID), Request: txID, Response: &response})
 	return response, err
 }
 
@@ -0,0 +1,104 @@
+package nursys
+
+import (
+	"strconv"
+	"time"
+)
+
+// Metric names reported to a MetricsRecorder.
+const (
+	MetricRequests            = "nursys_requests_total"             // Counter of HTTP requests, by endpoint and status.
+	MetricRequestDuration     = "nursys_request_duration_seconds"   // Histogram of HTTP request latency, by endpoint.
+	MetricTransactionFailures = "nursys_transaction_failures_total" // Counter of responses with TransactionSuccessFlag false, by endpoint and error_id.
+	MetricPollIterations      = "nursys_poll_iterations"            // Histogram of result requests made by the Poll helpers until processing completed, by endpoint.
+	MetricBatchSize           = "nursys_batch_size"                 // Histogram of the number of nurses or documents per request, by endpoint.
+)
+
+// Metric label names.
+const (
+	LabelEndpoint    = "endpoint"    // One of the Endpoint constants.
+	LabelStatus      = "status"      // HTTP status code, or "error" if no response was received.
+	LabelErrorID     = "error_id"    // TransactionError.ErrorID, or "" if the transaction reported no error.
+	LabelInstitution = "institution" // Institution ID of a ClientPool client.
+)
+
+// Label is a metric label.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// MetricsRecorder receives the client's metrics. Implementations adapt it to a metrics system and must be
+// safe for concurrent use. See PrometheusRecorder for a built-in implementation.
+type MetricsRecorder interface {
+	// Count adds delta to a counter.
+	Count(name string, delta float64, labels ...Label)
+	// Observe records a value in a histogram.
+	Observe(name string, value float64, labels ...Label)
+}
+
+// WithMetrics makes the client report metrics about every HTTP request to recorder: request counts by
+// status, latency, transaction failures by error ID and batch sizes. The Poll helpers also report the
+// number of result requests made until processing completed when given a client with metrics.
+func WithMetrics(recorder MetricsRecorder) ClientOption {
+	return func(c *nsHTTPClient) {
+		c.metrics = recorder
+	}
+}
+
+// recordCall reports the metrics of a call sent over HTTP.
+func (c *nsHTTPClient) recordCall(call *Call, status int, duration time.Duration, err error) {
+	if c.metrics == nil {
+		return
+	}
+	endpoint := Label{LabelEndpoint, call.Endpoint}
+	statusLabel := Label{LabelStatus, "error"}
+	if status != 0 {
+		statusLabel.Value = strconv.Itoa(status)
+	}
+	c.metrics.Count(MetricRequests, 1, endpoint, statusLabel)
+	c.metrics.Observe(MetricRequestDuration, duration.Seconds(), endpoint)
+	if n, ok := batchSize(call.Request); ok {
+		c.metrics.Observe(MetricBatchSize, float64(n), endpoint)
+	}
+	if err != nil {
+		return
+	}
+	if tx, ok := transactionOf(call.Response); ok && !tx.TransactionSuccessFlag {
+		if len(tx.TransactionErrors) == 0 {
+			c.metrics.Count(MetricTransactionFailures, 1, endpoint, Label{LabelErrorID, ""})
+		}
+		for _, e := range tx.TransactionErrors {
+			c.metrics.Count(MetricTransactionFailures, 1, endpoint, Label{LabelErrorID, strconv.FormatInt(e.ErrorID, 10)})
+		}
+	}
+}
+
+// batchSize returns the number of nurses or documents in a request.
+func batchSize(request interface{}) (int, bool) {
+	switch r := request.(type) {
+	case ManageNurseListSubmitRequestMessage:
+		return len(r.ManageNurseListRequests), true
+	case NurseLookupSubmitRequestMessage:
+		return len(r.NurseLookupRequests), true
+	case []string:
+		return len(r), true
+	}
+	return 0, false
+}
+
+// metricsRecorder is implemented by clients created with WithMetrics.
+type metricsRecorder interface {
+	metricsRecorder() MetricsRecorder
+}
+
+func (c *nsHTTPClient) metricsRecorder() MetricsRecorder {
+	return c.metrics
+}
+
+// recordPoll reports the number of result requests a Poll helper made until processing completed.
+func recordPoll(c Client, endpoint string, iterations int) {
+	if m, ok := c.(metricsRecorder); ok && m.metricsRecorder() != nil {
+		m.metricsRecorder().Observe(MetricPollIterations, float64(iterations), Label{LabelEndpoint, endpoint})
+	}
+}
@@ -0,0 +1,115 @@
+package nursys_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/connectRN/go-nursys"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithMetrics(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.URL.Path == "/changepassword":
+			rw.Write([]byte(`{"Transaction": {"TransactionId": "tx-2", "TransactionSuccessFlag": false,
+				"TransactionErrors": [{"ErrorID": 210, "ErrorMessage": "Invalid password"}]}}`))
+		case req.Method == http.MethodPost:
+			rw.Write(submitResponseJSON)
+		case req.URL.Path == "/managenurselist":
+			polls++
+			rw.Write([]byte(`{"ProcessingCompleteFlag": ` + strconv.FormatBool(polls == 3) + `}`))
+		default:
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	metrics := nursys.NewPrometheusRecorder(nursys.PrometheusRecorderConfig{})
+	client := nursys.New(server.URL, "acme", "1234!", nursys.WithMetrics(metrics))
+
+	_, err := client.ManageNurseList(ctx, nursys.ManageNurseListSubmitRequestMessage{
+		ManageNurseListRequests: []nursys.ManageNurseListRequest{testNurse("1"), testNurse("2")},
+	})
+	require.NoError(t, err)
+	_, err = client.ChangePassword(ctx, nursys.ChangePasswordSubmitRequestMessage{NewPassword: "x"})
+	require.NoError(t, err)
+	_, err = client.RetrieveDocuments(ctx, []string{"doc-1"})
+	assert.Error(err)
+	_, err = nursys.PollManageNurseListResult(ctx, client, "tx-1", time.Millisecond)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Contains(rec.Header().Get("Content-Type"), "text/plain")
+	body := rec.Body.String()
+	for _, line := range []string{
+		"# TYPE nursys_requests_total counter",
+		`nursys_requests_total{endpoint="ManageNurseList",status="200"} 1`,
+		`nursys_requests_total{endpoint="ChangePassword",status="200"} 1`,
+		`nursys_requests_total{endpoint="RetrieveDocuments",status="500"} 1`,
+		`nursys_requests_total{endpoint="GetManageNurseListResult",status="200"} 3`,
+		`nursys_transaction_failures_total{endpoint="ChangePassword",error_id="210"} 1`,
+		"# TYPE nursys_batch_size histogram",
+		`nursys_batch_size_bucket{endpoint="ManageNurseList",le="1"} 0`,
+		`nursys_batch_size_bucket{endpoint="ManageNurseList",le="5"} 1`,
+		`nursys_batch_size_sum{endpoint="ManageNurseList"} 2`,
+		`nursys_batch_size_count{endpoint="RetrieveDocuments"} 1`,
+		`nursys_poll_iterations_bucket{endpoint="GetManageNurseListResult",le="2"} 0`,
+		`nursys_poll_iterations_bucket{endpoint="GetManageNurseListResult",le="3"} 1`,
+		`nursys_request_duration_seconds_count{endpoint="ManageNurseList"} 1`,
+		`nursys_request_duration_seconds_bucket{endpoint="ManageNurseList",le="+Inf"} 1`,
+	} {
+		assert.Contains(body, line+"\n")
+	}
+	assert.NotContains(body, `nursys_transaction_failures_total{endpoint="ManageNurseList"`)
+}
+
+func Test_PrometheusRecorder_Escaping(t *testing.T) {
+	metrics := nursys.NewPrometheusRecorder(nursys.PrometheusRecorderConfig{})
+	metrics.Count("requests", 2, nursys.Label{Name: "b", Value: "x\"y\\z\n"}, nursys.Label{Name: "a", Value: "1"})
+	metrics.Count("plain", 1.5)
+
+	var buf bytes.Buffer
+	_, err := metrics.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{
+		"# TYPE plain counter",
+		"plain 1.5",
+		"# TYPE requests counter",
+		`requests{a="1",b="x\"y\\z\n"} 2`,
+		"",
+	}, "\n"), buf.String())
+}
+
+func Test_PrometheusRecorder_Buckets(t *testing.T) {
+	buckets := nursys.DefaultPrometheusBuckets()
+	buckets[nursys.MetricBatchSize] = []float64{10, 100}
+	metrics := nursys.NewPrometheusRecorder(nursys.PrometheusRecorderConfig{Buckets: buckets})
+
+	// Neither the config nor the defaults are shared with the recorder.
+	buckets[nursys.MetricBatchSize][0] = 1
+	nursys.DefaultPrometheusBuckets()[nursys.MetricPollIterations][0] = 1000
+	metrics.Observe(nursys.MetricBatchSize, 5)
+	metrics.Observe(nursys.MetricPollIterations, 1)
+
+	var buf bytes.Buffer
+	_, err := metrics.WriteTo(&buf)
+	require.NoError(t, err)
+	body := buf.String()
+	assert.Contains(t, body, `nursys_batch_size_bucket{le="10"} 1`+"\n")
+	assert.NotContains(t, body, `nursys_batch_size_bucket{le="1"}`)
+	assert.Contains(t, body, `nursys_poll_iterations_bucket{le="1"} 1`+"\n")
+}
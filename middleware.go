@@ -0,0 +1,121 @@
+package nursys
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Endpoint names identify the Client method a Call was made by.
+const (
+	EndpointManageNurseList             = "ManageNurseList"
+	EndpointGetManageNurseListResult    = "GetManageNurseListResult"
+	EndpointChangePassword              = "ChangePassword"
+	EndpointNurseLookup                 = "NurseLookup"
+	EndpointGetNurseLookupResult        = "GetNurseLookupResult"
+	EndpointNotificationLookup          = "NotificationLookup"
+	EndpointGetNotificationLookupResult = "GetNotificationLookupResult"
+	EndpointRetrieveDocuments           = "RetrieveDocuments"
+)
+
+// Call is a single API request as seen by middleware.
+type Call struct {
+	Endpoint string // Name of the Client method making the call, one of the Endpoint constants.
+	Method   string // HTTP method.
+	Path     string // Path and query, relative to the base URL.
+
+	// Request is the typed argument of the Client method: the request message of POST methods, the
+	// transaction ID (string) of Get...Result methods, or the document IDs ([]string) of RetrieveDocuments.
+	// Only the request messages of POST methods are sent as the request body.
+	Request interface{}
+
+	// Response is a pointer to the typed response message of the Client method. It is populated once the
+	// next Invoker returns without error.
+	Response interface{}
+}
+
+// Invoker performs a Call.
+type Invoker func(ctx context.Context, call *Call) error
+
+// Middleware wraps an Invoker. Middleware can inspect or change the call before invoking next, inspect
+// the decoded response or error afterwards, or refuse the call by returning an error without invoking next.
+type Middleware func(next Invoker) Invoker
+
+// WithMiddleware adds middleware around every API call made by the client. Middleware runs in the order
+// given, the first being the outermost; repeated WithMiddleware options append to the chain.
+//
+// For example, to block password changes:
+//
+//	nursys.WithMiddleware(func(next nursys.Invoker) nursys.Invoker {
+//		return func(ctx context.Context, call *nursys.Call) error {
+//			if call.Endpoint == nursys.EndpointChangePassword {
+//				return errors.New("password changes are disabled")
+//			}
+//			return next(ctx, call)
+//		}
+//	})
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(c *nsHTTPClient) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// invoke performs the call through the middleware chain.
+func (c *nsHTTPClient) invoke(ctx context.Context, call *Call) error {
+	if c.configErr != nil {
+		return c.configErr
+	}
+	invoker := c.send
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		invoker = c.middleware[i](invoker)
+	}
+	return invoker(ctx, call)
+}
+
+// send is the innermost Invoker, sending the call over HTTP.
+func (c *nsHTTPClient) send(ctx context.Context, call *Call) error {
+	var body interface{}
+	if call.Method == http.MethodPost {
+		body = call.Request
+	}
+	if c.strict {
+		if err := ValidateLicenseTypes(call.Request); err != nil {
+			return fmt.Errorf("nursys: %s request: %w", call.Endpoint, err)
+		}
+	}
+	var generation uint64
+	if c.breaker != nil {
+		var err error
+		if generation, err = c.breaker.allow(call.Endpoint); err != nil {
+			return err
+		}
+	}
+	if c.limiter != nil {
+		release, err := c.limiter.wait(ctx, call.Endpoint)
+		if err != nil {
+			if c.breaker != nil {
+				c.breaker.cancel(call.Endpoint, generation)
+			}
+			return err
+		}
+		defer release()
+	}
+	ctx, endSpan := c.traceCall(ctx, call)
+	start := time.Now()
+	status, err := c.do(ctx, call.Method, call.Path, body, call.Response)
+	c.recordCall(call, status, time.Since(start), err)
+	endSpan(status, err)
+	if c.limiter != nil {
+		c.limiter.observe(call.Endpoint, err)
+	}
+	if c.breaker != nil {
+		c.breaker.record(call.Endpoint, generation, err)
+	}
+	if err == nil && c.strict {
+		if err := ValidateLicenseTypes(call.Response); err != nil {
+			return fmt.Errorf("nursys: %s response: %w", call.Endpoint, err)
+		}
+	}
+	return err
+}
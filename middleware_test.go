@@ -0,0 +1,84 @@
+package nursys_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/connectRN/go-nursys"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithMiddleware(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests++
+		rw.Write(submitResponseJSON)
+	}))
+	t.Cleanup(server.Close)
+
+	var trace []string
+	record := func(name string) nursys.Middleware {
+		return func(next nursys.Invoker) nursys.Invoker {
+			return func(ctx context.Context, call *nursys.Call) error {
+				trace = append(trace, name+" before "+call.Endpoint)
+				err := next(ctx, call)
+				trace = append(trace, name+" after "+call.Endpoint)
+				return err
+			}
+		}
+	}
+	errBlocked := errors.New("blocked")
+	block := func(next nursys.Invoker) nursys.Invoker {
+		return func(ctx context.Context, call *nursys.Call) error {
+			if call.Endpoint == nursys.EndpointChangePassword {
+				return errBlocked
+			}
+			return next(ctx, call)
+		}
+	}
+	var seen []*nursys.Call
+	observe := func(next nursys.Invoker) nursys.Invoker {
+		return func(ctx context.Context, call *nursys.Call) error {
+			err := next(ctx, call)
+			seen = append(seen, call)
+			return err
+		}
+	}
+
+	client := nursys.New(server.URL, "acme", "1234!",
+		nursys.WithMiddleware(record("outer"), block),
+		nursys.WithMiddleware(observe, record("inner")))
+
+	request := nursys.NurseLookupSubmitRequestMessage{NurseLookupRequests: []nursys.NurseLookupRequest{{NcsbnID: "12345"}}}
+	resp, err := client.NurseLookup(ctx, request)
+	require.NoError(t, err)
+	assert.NotEmpty(resp.TransactionID)
+
+	_, err = client.ChangePassword(ctx, nursys.ChangePasswordSubmitRequestMessage{NewPassword: "N3w!"})
+	assert.ErrorIs(err, errBlocked)
+	assert.Equal(1, requests)
+
+	assert.Equal([]string{
+		"outer before NurseLookup",
+		"inner before NurseLookup",
+		"inner after NurseLookup",
+		"outer after NurseLookup",
+		"outer before ChangePassword",
+		"outer after ChangePassword",
+	}, trace)
+
+	require.Len(t, seen, 1)
+	assert.Equal("POST", seen[0].Method)
+	assert.Equal("/nurselookup", seen[0].Path)
+	assert.Equal(request, seen[0].Request)
+	require.IsType(t, &nursys.ManageNurseListSubmitResponseMessage{}, seen[0].Response)
+	assert.Equal(resp, *seen[0].Response.(*nursys.ManageNurseListSubmitResponseMessage))
+}
@@ -0,0 +1,304 @@
+package nursys
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// The NPDB code tables are embedded from the codes directory. Each file has a header row and one row per
+// code. They are transcribed from the state licensure action and basis for action sections of the NPDB
+// Code Lists published by HRSA at npdb.hrsa.gov, and cover state licensure actions only. Classifying by
+// code keeps results stable when Nursys descriptions vary in wording. When the Code Lists change, update
+// the tables from the new edition and keep the constants below in sync with them.
+var (
+	//go:embed codes/npdb_action_codes.csv
+	npdbActionCodesCSV string
+	//go:embed codes/npdb_basis_codes.csv
+	npdbBasisCodesCSV string
+)
+
+// NPDBActionCode is a National Practitioner Data Bank adverse action classification code, as used in
+// NurseLookupAction.ActionCode.
+type NPDBActionCode string
+
+// NPDB action codes for state licensure actions
+const (
+	NPDBActionRevocation                  NPDBActionCode = "1110"
+	NPDBActionRevocationOfPrivilege       NPDBActionCode = "1115"
+	NPDBActionRevocationOfRightToRenew    NPDBActionCode = "1120"
+	NPDBActionSuspension                  NPDBActionCode = "1130"
+	NPDBActionSummarySuspension           NPDBActionCode = "1135"
+	NPDBActionSuspensionOfPrivilege       NPDBActionCode = "1136"
+	NPDBActionVoluntarySurrender          NPDBActionCode = "1140"
+	NPDBActionSurrenderUnderInvestigation NPDBActionCode = "1141"
+	NPDBActionSurrenderOfPrivilege        NPDBActionCode = "1142"
+	NPDBActionProbation                   NPDBActionCode = "1145"
+	NPDBActionProbationOfPrivilege        NPDBActionCode = "1146"
+	NPDBActionRestriction                 NPDBActionCode = "1150"
+	NPDBActionSummaryRestriction          NPDBActionCode = "1155"
+	NPDBActionVoluntaryRestriction        NPDBActionCode = "1156"
+	NPDBActionRestrictionOfPrivilege      NPDBActionCode = "1157"
+	NPDBActionDenial                      NPDBActionCode = "1160"
+	NPDBActionDenialOfRenewal             NPDBActionCode = "1161"
+	NPDBActionDenialOfReinstatement       NPDBActionCode = "1162"
+	NPDBActionDenialOfPrivilege           NPDBActionCode = "1163"
+	NPDBActionReprimand                   NPDBActionCode = "1170"
+	NPDBActionPublicReprimand             NPDBActionCode = "1171"
+	NPDBActionLetterOfConcern             NPDBActionCode = "1172"
+	NPDBActionFine                        NPDBActionCode = "1173"
+	NPDBActionCivilPenalty                NPDBActionCode = "1174"
+	NPDBActionReinstatement               NPDBActionCode = "1180"
+	NPDBActionReinstatementWithConditions NPDBActionCode = "1181"
+	NPDBActionReinstatementOfPrivilege    NPDBActionCode = "1182"
+	NPDBActionOther                       NPDBActionCode = "1190"
+)
+
+// NPDBBasisCode is a National Practitioner Data Bank basis for action code, as used in
+// NurseLookupBasisForAction.BasisForActionCode.
+type NPDBBasisCode string
+
+// NPDB basis for action codes
+const (
+	NPDBBasisSubstandardCare             NPDBBasisCode = "10"
+	NPDBBasisSubstandardSkill            NPDBBasisCode = "11"
+	NPDBBasisRecordKeeping               NPDBBasisCode = "12"
+	NPDBBasisPatientAbandonment          NPDBBasisCode = "13"
+	NPDBBasisScopeOfPractice             NPDBBasisCode = "14"
+	NPDBBasisIllness                     NPDBBasisCode = "15"
+	NPDBBasisSubstanceAbuse              NPDBBasisCode = "16"
+	NPDBBasisDiversion                   NPDBBasisCode = "20"
+	NPDBBasisImproperPrescribing         NPDBBasisCode = "21"
+	NPDBBasisControlledSubstanceLaw      NPDBBasisCode = "22"
+	NPDBBasisUnprofessionalConduct       NPDBBasisCode = "30"
+	NPDBBasisSexualMisconduct            NPDBBasisCode = "31"
+	NPDBBasisPatientAbuse                NPDBBasisCode = "32"
+	NPDBBasisBoundaryViolation           NPDBBasisCode = "33"
+	NPDBBasisLicenseFraud                NPDBBasisCode = "40"
+	NPDBBasisBillingFraud                NPDBBasisCode = "41"
+	NPDBBasisCredentialMisrepresentation NPDBBasisCode = "42"
+	NPDBBasisRecordFalsification         NPDBBasisCode = "43"
+	NPDBBasisCriminalConviction          NPDBBasisCode = "50"
+	NPDBBasisGuiltyPlea                  NPDBBasisCode = "51"
+	NPDBBasisOtherStateAction            NPDBBasisCode = "60"
+	NPDBBasisUnlicensedPractice          NPDBBasisCode = "61"
+	NPDBBasisContinuingEducation         NPDBBasisCode = "62"
+	NPDBBasisBoardOrderViolation         NPDBBasisCode = "63"
+	NPDBBasisOther                       NPDBBasisCode = "99"
+)
+
+// ActionCategory groups NPDB actions of the same kind, whatever their exact code and wording.
+type ActionCategory string
+
+// Action categories, most severe first
+const (
+	ActionCategoryRevocation    ActionCategory = "revocation"
+	ActionCategorySurrender     ActionCategory = "surrender"
+	ActionCategorySuspension    ActionCategory = "suspension"
+	ActionCategoryDenial        ActionCategory = "denial"
+	ActionCategoryRestriction   ActionCategory = "restriction"
+	ActionCategoryProbation     ActionCategory = "probation"
+	ActionCategoryReprimand     ActionCategory = "reprimand"
+	ActionCategoryFine          ActionCategory = "fine"
+	ActionCategoryOther         ActionCategory = "other"
+	ActionCategoryReinstatement ActionCategory = "reinstatement"
+)
+
+var actionCategories = []ActionCategory{
+	ActionCategoryRevocation,
+	ActionCategorySurrender,
+	ActionCategorySuspension,
+	ActionCategoryDenial,
+	ActionCategoryRestriction,
+	ActionCategoryProbation,
+	ActionCategoryReprimand,
+	ActionCategoryFine,
+	ActionCategoryOther,
+	ActionCategoryReinstatement,
+}
+
+// ActionCategories returns all action categories, most severe first.
+func ActionCategories() []ActionCategory {
+	return append([]ActionCategory(nil), actionCategories...)
+}
+
+// Severity ranks the category: the higher, the more severe. Reinstatements rank 0, unknown categories -1.
+func (c ActionCategory) Severity() int {
+	for i, cat := range actionCategories {
+		if cat == c {
+			return len(actionCategories) - 1 - i
+		}
+	}
+	return -1
+}
+
+// Restrictive reports whether actions of the category restrict the right to practice, as opposed to
+// reprimands, fines and similar actions that do not.
+func (c ActionCategory) Restrictive() bool {
+	switch c {
+	case ActionCategoryRevocation, ActionCategorySurrender, ActionCategorySuspension, ActionCategoryDenial,
+		ActionCategoryRestriction, ActionCategoryProbation:
+		return true
+	}
+	return false
+}
+
+// BasisGroup groups NPDB basis for action codes by the kind of conduct they describe.
+type BasisGroup string
+
+// Basis for action groups
+const (
+	BasisGroupPractice   BasisGroup = "practice"   // Competence, care and documentation.
+	BasisGroupImpairment BasisGroup = "impairment" // Inability to practice safely because of illness or substance abuse.
+	BasisGroupSubstances BasisGroup = "substances" // Diversion and other controlled substance violations.
+	BasisGroupConduct    BasisGroup = "conduct"    // Unprofessional conduct, misconduct and patient abuse.
+	BasisGroupFraud      BasisGroup = "fraud"      // Fraud, deception and falsification.
+	BasisGroupCriminal   BasisGroup = "criminal"   // Criminal convictions and pleas.
+	BasisGroupLicensure  BasisGroup = "licensure"  // Licensure requirements, board orders and actions by other boards.
+	BasisGroupOther      BasisGroup = "other"
+)
+
+// NPDBActionCodeInfo describes an NPDB action code.
+type NPDBActionCodeInfo struct {
+	Code        NPDBActionCode
+	Description string
+	Category    ActionCategory
+}
+
+// NPDBBasisCodeInfo describes an NPDB basis for action code.
+type NPDBBasisCodeInfo struct {
+	Code        NPDBBasisCode
+	Description string
+	Group       BasisGroup
+}
+
+var (
+	npdbActionCodes = loadNPDBCodes(npdbActionCodesCSV, func(r []string) NPDBActionCodeInfo {
+		return NPDBActionCodeInfo{Code: NPDBActionCode(r[0]), Description: r[1], Category: ActionCategory(r[2])}
+	})
+	npdbBasisCodes = loadNPDBCodes(npdbBasisCodesCSV, func(r []string) NPDBBasisCodeInfo {
+		return NPDBBasisCodeInfo{Code: NPDBBasisCode(r[0]), Description: r[1], Group: BasisGroup(r[2])}
+	})
+)
+
+// loadNPDBCodes parses an embedded code table into a map keyed by code.
+func loadNPDBCodes[T any](table string, parse func([]string) T) map[string]T {
+	rows, err := csv.NewReader(strings.NewReader(table)).ReadAll()
+	if err != nil || len(rows) == 0 {
+		panic(fmt.Sprintf("nursys: malformed embedded NPDB code table: %v", err))
+	}
+	codes := make(map[string]T, len(rows)-1)
+	for _, r := range rows[1:] {
+		codes[r[0]] = parse(r)
+	}
+	return codes
+}
+
+// NPDBActionCodes returns the NPDB action codes known to this package, ordered by code.
+func NPDBActionCodes() []NPDBActionCodeInfo {
+	list := make([]NPDBActionCodeInfo, 0, len(npdbActionCodes))
+	for _, code := range sortedKeys(npdbActionCodes) {
+		list = append(list, npdbActionCodes[code])
+	}
+	return list
+}
+
+// Info returns the description and category of the code, and whether the code is known.
+func (c NPDBActionCode) Info() (NPDBActionCodeInfo, bool) {
+	info, ok := npdbActionCodes[strings.TrimSpace(string(c))]
+	return info, ok
+}
+
+// Category returns the category of the code, or the empty string if the code is not known.
+func (c NPDBActionCode) Category() ActionCategory {
+	info, _ := c.Info()
+	return info.Category
+}
+
+// NPDBBasisCodes returns the NPDB basis for action codes known to this package, ordered by code.
+func NPDBBasisCodes() []NPDBBasisCodeInfo {
+	list := make([]NPDBBasisCodeInfo, 0, len(npdbBasisCodes))
+	for _, code := range sortedKeys(npdbBasisCodes) {
+		list = append(list, npdbBasisCodes[code])
+	}
+	return list
+}
+
+// Info returns the description and group of the code, and whether the code is known.
+func (c NPDBBasisCode) Info() (NPDBBasisCodeInfo, bool) {
+	info, ok := npdbBasisCodes[strings.TrimSpace(string(c))]
+	return info, ok
+}
+
+// Group returns the group of the code, or the empty string if the code is not known.
+func (c NPDBBasisCode) Group() BasisGroup {
+	info, _ := c.Info()
+	return info.Group
+}
+
+// actionCategoryWords classify action descriptions when the code is not in the table. They are checked
+// in order, so that e.g. "Reinstatement of License with Conditions" is a reinstatement, not a restriction,
+// and "Denial of Reinstatement" is a denial, not a reinstatement.
+var actionCategoryWords = []struct {
+	word     string
+	category ActionCategory
+}{
+	{"denial", ActionCategoryDenial},
+	{"denied", ActionCategoryDenial},
+	{"reinstat", ActionCategoryReinstatement},
+	{"revoc", ActionCategoryRevocation},
+	{"revok", ActionCategoryRevocation},
+	{"surrender", ActionCategorySurrender},
+	{"suspen", ActionCategorySuspension},
+	{"probation", ActionCategoryProbation},
+	{"restrict", ActionCategoryRestriction},
+	{"limit", ActionCategoryRestriction},
+	{"condition", ActionCategoryRestriction},
+	{"cease", ActionCategoryRestriction},
+	{"reprimand", ActionCategoryReprimand},
+	{"censure", ActionCategoryReprimand},
+	{"warning", ActionCategoryReprimand},
+	{"concern", ActionCategoryReprimand},
+	{"fine", ActionCategoryFine},
+	{"penalty", ActionCategoryFine},
+	{"monetary", ActionCategoryFine},
+}
+
+// Category returns the category of the action from its ActionCode. Codes that are not in the embedded
+// table are classified from ActionDescription, falling back to ActionCategoryOther.
+func (a NurseLookupAction) Category() ActionCategory {
+	if category := a.ActionCode.Category(); category != "" {
+		return category
+	}
+	desc := strings.ToLower(a.ActionDescription)
+	for _, w := range actionCategoryWords {
+		if strings.Contains(desc, w.word) {
+			return w.category
+		}
+	}
+	return ActionCategoryOther
+}
+
+// Severity returns the severity rank of the action's category. See ActionCategory.Severity.
+func (a NurseLookupAction) Severity() int {
+	return a.Category().Severity()
+}
+
+// Group returns the group of the basis for action from its BasisForActionCode, or BasisGroupOther if the
+// code is not in the embedded table.
+func (b NurseLookupBasisForAction) Group() BasisGroup {
+	if group := b.BasisForActionCode.Group(); group != "" {
+		return group
+	}
+	return BasisGroupOther
+}
+
+// SortActionsBySeverity sorts actions from the most to the least severe, keeping the original order of
+// actions of the same severity.
+func SortActionsBySeverity(actions []NurseLookupAction) {
+	sort.SliceStable(actions, func(i, j int) bool {
+		return actions[i].Severity() > actions[j].Severity()
+	})
+}
@@ -0,0 +1,79 @@
+package nursys_test
+
+import (
+	"testing"
+
+	"github.com/connectRN/go-nursys"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NPDBCodeTables(t *testing.T) {
+	assert := assert.New(t)
+	actions := nursys.NPDBActionCodes()
+	assert.NotEmpty(actions)
+	for _, info := range actions {
+		assert.NotEmpty(info.Description, info.Code)
+		assert.GreaterOrEqual(info.Category.Severity(), 0, info.Code)
+	}
+	bases := nursys.NPDBBasisCodes()
+	assert.NotEmpty(bases)
+	for _, info := range bases {
+		assert.NotEmpty(info.Description, info.Code)
+		assert.NotEmpty(info.Group, info.Code)
+	}
+
+	info, ok := nursys.NPDBActionProbation.Info()
+	assert.True(ok)
+	assert.Equal(nursys.ActionCategoryProbation, info.Category)
+	assert.Equal(nursys.BasisGroupSubstances, nursys.NPDBBasisDiversion.Group())
+	_, ok = nursys.NPDBActionCode("9999").Info()
+	assert.False(ok)
+}
+
+func Test_NurseLookupAction_Category(t *testing.T) {
+	for _, tc := range []struct {
+		action nursys.NurseLookupAction
+		want   nursys.ActionCategory
+	}{
+		{nursys.NurseLookupAction{ActionCode: nursys.NPDBActionRevocation, ActionDescription: "Board order"}, nursys.ActionCategoryRevocation},
+		{nursys.NurseLookupAction{ActionCode: " 1173 "}, nursys.ActionCategoryFine},
+		{nursys.NurseLookupAction{ActionCode: "9999", ActionDescription: "SUSPENDED - EMERGENCY"}, nursys.ActionCategorySuspension},
+		{nursys.NurseLookupAction{ActionDescription: "Reinstated with conditions"}, nursys.ActionCategoryReinstatement},
+		{nursys.NurseLookupAction{ActionDescription: "Denial of Reinstatement"}, nursys.ActionCategoryDenial},
+		{nursys.NurseLookupAction{ActionDescription: "Reinstatement Denied"}, nursys.ActionCategoryDenial},
+		{nursys.NurseLookupAction{ActionDescription: "Consent agreement"}, nursys.ActionCategoryOther},
+		// The code wins over a description worded differently.
+		{nursys.NurseLookupAction{ActionCode: nursys.NPDBActionDenialOfReinstatement, ActionDescription: "Reinstatement - not granted"}, nursys.ActionCategoryDenial},
+		{nursys.NurseLookupAction{ActionCode: nursys.NPDBActionProbation, ActionDescription: "Placed on monitoring"}, nursys.ActionCategoryProbation},
+	} {
+		assert.Equal(t, tc.want, tc.action.Category(), tc.action.ActionDescription)
+	}
+
+	assert.Equal(t, nursys.BasisGroupOther, nursys.NurseLookupBasisForAction{BasisForActionCode: "ZZ"}.Group())
+	assert.Equal(t, nursys.BasisGroupFraud, nursys.NurseLookupBasisForAction{BasisForActionCode: nursys.NPDBBasisLicenseFraud}.Group())
+}
+
+func Test_SortActionsBySeverity(t *testing.T) {
+	actions := []nursys.NurseLookupAction{
+		{ActionCode: nursys.NPDBActionFine},
+		{ActionCode: nursys.NPDBActionProbation},
+		{ActionCode: nursys.NPDBActionReinstatement},
+		{ActionCode: nursys.NPDBActionRevocation},
+		{ActionCode: nursys.NPDBActionSummarySuspension},
+		{ActionCode: nursys.NPDBActionSuspension},
+	}
+	nursys.SortActionsBySeverity(actions)
+	var codes []nursys.NPDBActionCode
+	for _, a := range actions {
+		codes = append(codes, a.ActionCode)
+	}
+	assert.Equal(t, []nursys.NPDBActionCode{
+		nursys.NPDBActionRevocation,
+		nursys.NPDBActionSummarySuspension,
+		nursys.NPDBActionSuspension,
+		nursys.NPDBActionProbation,
+		nursys.NPDBActionFine,
+		nursys.NPDBActionReinstatement,
+	}, codes)
+}
@@ -11,7 +11,7 @@ import (
 // information for all licenses for those nurses within Nursys.
 func (c *nsHTTPClient) NurseLookup(ctx context.Context, request NurseLookupSubmitRequestMessage) (ManageNurseListSubmitResponseMessage, error) {
 	var response ManageNurseListSubmitResponseMessage
-	err := c.invokeEndpoint(ctx, "POST", "/nurselookup", request, &response)
+	err := c.invoke(ctx, &Call{Endpoint: EndpointNurseLookup, Method: "POST", Path: "/nurselookup", Request: request, Response: &response})
 	return response, err
 }
 
@@ -29,11 +29,11 @@ type NurseLookupSubmitRequestMessage struct {
 //	License Type, NCSBN ID
 //	NCSBN ID
 type NurseLookupRequest struct {
-	JurisdictionAbbreviation string `json:"JurisdictionAbbreviation,omitempty"` // Optional 4 State board of nursing. Please see the appendix for a list of valid values.
-	LicenseNumber            string `json:"LicenseNumber,omitempty"`            // Optional 15 License number.
-	LicenseType              string `json:"LicenseType,omitempty"`              // Optional 4 License type. Please see the appendix for a list of valid values.
-	NcsbnID                  string `json:"NcsbnId,omitempty"`                  // Optional 10 NCSBN ID is the public, globally unique identifier for all nurses from participating boards of nursing.
-	RecordID                 string `json:"RecordId,omitempty"`                 // Optional 50 Client-provided id
+	JurisdictionAbbreviation Jurisdiction `json:"JurisdictionAbbreviation,omitempty"` // Optional 4 State board of nursing. Please see the appendix for a list of valid values.
+	LicenseNumber            string       `json:"LicenseNumber,omitempty"`            // Optional 15 License number.
+	LicenseType              LicenseType  `json:"LicenseType,omitempty"`              // Optional 4 License type. Please see the appendix for a list of valid values.
+	NcsbnID                  string       `json:"NcsbnId,omitempty"`                  // Optional 10 NCSBN ID is the public, globally unique identifier for all nurses from participating boards of nursing.
+	RecordID                 string       `json:"RecordId,omitempty"`                 // Optional 50 Client-provided id
 }
 
 // The NurseLookupSubmitResponseMessage models the response from the Nurse Lookup HTTP POST method.
@@ -44,7 +44,7 @@ type NurseLookupSubmitResponseMessage struct {
 // GetManageNurseListResult retrieves the results of a NurseLookup transaction.
 func (c *nsHTTPClient) GetNurseLookupResult(ctx context.Context, txID string) (NurseLookupRetrieveResponseMessage, error) {
 	var response NurseLookupRetrieveResponseMessage
-	err := c.invokeEndpoint(ctx, "GET", "/nurselookup?transactionId="+url.QueryEscape(txID), nil, &response)
+	err := c.invoke(ctx, &Call{Endpoint: EndpointGetNurseLookupResult, Method: "GET", Path: "/nurselookup?transactionId=" + url.QueryEscape(txID), Request: txID, Response: &response})
 	return response, err
 }
 
@@ -73,14 +73,14 @@ type NurseLookupResponse struct {
 type NurseLookupLicense struct {
 	LastName                     string                        `json:"LastName"`                     //  Required 50 Licensee last name.
 	FirstName                    string                        `json:"FirstName"`                    //  Required 50 Licensee first name.
-	LicenseType                  string                        `json:"LicenseType"`                  //  Required 4 License type. See appendix for a list of valid values.
-	JurisdictionAbbreviation     string                        `json:"JurisdictionAbbreviation"`     //  Required 4 State board of nursing abbreviation. See appendix for a list of valid values.
+	LicenseType                  LicenseType                   `json:"LicenseType"`                  //  Required 4 License type. See appendix for a list of valid values.
+	JurisdictionAbbreviation     Jurisdiction                  `json:"JurisdictionAbbreviation"`     //  Required 4 State board of nursing abbreviation. See appendix for a list of valid values.
 	Jurisdiction                 string                        `json:"Jurisdiction"`                 //  Required 50 State board of nursing description. See appendix for a list of valid values.
 	LicenseNumber                string                        `json:"LicenseNumber"`                //  Required 15 License number.
 	Active                       string                        `json:"Active"`                       //  Optional 50 Active status for the license.
 	LicenseStatus                string                        `json:"LicenseStatus"`                //  Optional 500 Current status of the license.
-	LicenseOriginalDate          string                        `json:"LicenseOriginalDate"`          //  Optional Original issue date for the license.
-	LicenseExpirationDate        string                        `json:"LicenseExpirationDate"`        //  Optional Expiration date for the license.
+	LicenseOriginalDate          string                        `json:"LicenseOriginalDate"`          //  Optional Original issue date for the license. See OriginalDate.
+	LicenseExpirationDate        string                        `json:"LicenseExpirationDate"`        //  Optional Expiration date for the license. See ExpirationDate.
 	CompactStatus                string                        `json:"CompactStatus"`                //  Optional 50 Nurse Licensure Compact (NLC) status of the license. Please visit nursys.com for more information about the NLC.
 	Messages                     []string                      `json:"Messages"`                     //  Optional A collection of notification messages regarding the license. It is vital to review these messages as they may contain important license information.
 	NurseLookupDisciplines       []NurseLookupDiscipline       `json:"NurseLookupDisciplines"`       //  Optional Collection of discipline information associated with this license.
@@ -90,7 +90,7 @@ type NurseLookupLicense struct {
 
 // NurseLookupDiscipline is an element of NurseLookupLicense
 type NurseLookupDiscipline struct {
-	JurisdictionAbbreviation          string                      `json:"JurisdictionAbbreviation"`          // Required 4 Abbreviation for the state board of nursing that took the discipline/final orders. See appendix for a list of valid values.
+	JurisdictionAbbreviation          Jurisdiction                `json:"JurisdictionAbbreviation"`          // Required 4 Abbreviation for the state board of nursing that took the discipline/final orders. See appendix for a list of valid values.
 	Jurisdiction                      string                      `json:"Jurisdiction"`                      // Required 50 Description for the state board of nursing that took the discipline/final orders. See appendix for a list of valid values.
 	DateActionWasTaken                Time                        `json:"DateActionWasTaken"`                // Required Date the discipline/final orders was taken.
 	AgainstPrivilegeToPracticeFlag    bool                        `json:"AgainstPrivilegeToPracticeFlag"`    // Required Flag to indicate if the discipline/final orders was taken against the license’s Privilege To Practice (PTP) as part of the Nurse Licensure Compact (NLC). Please visit nursys.com for more information about the NLC.
@@ -102,20 +102,20 @@ type NurseLookupDiscipline struct {
 
 // NurseLookupBasisForAction is an element of NurseLookupDiscipline
 type NurseLookupBasisForAction struct {
-	BasisForActionCode        string `json:"BasisForActionCode"`        // Required 2 NPDB code for this discipline/final order basis for action.
-	BasisForActionDescription string `json:"BasisForActionDescription"` // Required 150 NPDB description for this discipline/final order
+	BasisForActionCode        NPDBBasisCode `json:"BasisForActionCode"`        // Required 2 NPDB code for this discipline/final order basis for action.
+	BasisForActionDescription string        `json:"BasisForActionDescription"` // Required 150 NPDB description for this discipline/final order
 }
 
 // NurseLookupAction is an element of NurseLookupDiscipline or NurseLookupRevisionReport
 type NurseLookupAction struct {
-	ActionDate             Time   `json:"ActionDate"`             // Required Date of the discipline/final order action
-	ActionCode             string `json:"ActionCode"`             // Required 5 NPDB code for this discipline/final order action.
-	ActionDescription      string `json:"ActionDescription"`      // Required 150 NPDB description for this discipline/final order action.
-	ActionStayedFlag       bool   `json:"ActionStayedFlag"`       // Required Flag to indicate if this action has been stayed by the state board of nursing.
-	StartDate              Time   `json:"StartDate"`              // Optional Start date for this discipline/final order action.
-	EndDate                Time   `json:"EndDate"`                // Optional End date for this discipline/final order action.
-	Duration               string `json:"Duration"`               // Optional 50 Duration for this discipline/final order action (Indefinite/Unspecified, Permanent, or Specified)
-	AutomaticReinstatement string `json:"AutomaticReinstatement"` // Optional 50 Indicates if the license is automatically reinstated upon the conclusion of the discipline/final order (No, Yes, or Yes With Conditions)
+	ActionDate             Time           `json:"ActionDate"`             // Required Date of the discipline/final order action
+	ActionCode             NPDBActionCode `json:"ActionCode"`             // Required 5 NPDB code for this discipline/final order action.
+	ActionDescription      string         `json:"ActionDescription"`      // Required 150 NPDB description for this discipline/final order action.
+	ActionStayedFlag       bool           `json:"ActionStayedFlag"`       // Required Flag to indicate if this action has been stayed by the state board of nursing.
+	StartDate              Time           `json:"StartDate"`              // Optional Start date for this discipline/final order action.
+	EndDate                Time           `json:"EndDate"`                // Optional End date for this discipline/final order action.
+	Duration               string         `json:"Duration"`               // Optional 50 Duration for this discipline/final order action (Indefinite/Unspecified, Permanent, or Specified)
+	AutomaticReinstatement string         `json:"AutomaticReinstatement"` // Optional 50 Indicates if the license is automatically reinstated upon the conclusion of the discipline/final order (No, Yes, or Yes With Conditions)
 }
 
 // NurseLookupDocument is an element of NurseLookupDiscipline or NurseLookupRevisionReport or NurseLookupNotification
@@ -134,7 +134,7 @@ type NurseLookupRevisionReport struct {
 
 // NurseLookupNotification is an elemement of NurseLookupLicense
 type NurseLookupNotification struct {
-	JurisdictionAbbreviation string                `json:"JurisdictionAbbreviation"` //  Required 4 The state board of nursing that placed the member board notification on the license. See appendix for a list of valid values.
+	JurisdictionAbbreviation Jurisdiction          `json:"JurisdictionAbbreviation"` //  Required 4 The state board of nursing that placed the member board notification on the license. See appendix for a list of valid values.
 	Jurisdiction             string                `json:"Jurisdiction"`             //  Required 50 The state board of nursing that placed the member board notification on the license. See appendix for a list of valid values.
 	NotificationDate         Time                  `json:"NotificationDate"`         //  Required The date the member board notification was placed on the license.
 	NotificationMessage      string                `json:"NotificationMessage"`      //  Required 5000 The message text for the member board notification.
@@ -151,9 +151,9 @@ type NurseLookupAdvancedPractice struct {
 
 // AuthorizationToPractice is an element of NurseLookupResponse
 type AuthorizationToPractice struct {
-	StateAbbreviation                  string `json:"StateAbbreviation"`                  // Required 2 State. See appendix for a list of valid values.
-	StateDescription                   string `json:"StateDescription"`                   // Required 50 State. See appendix for a list of valid values.
-	AuthorizationToPracticeCode        string `json:"AuthorizationToPracticeCode"`        // Required 1 Code indicating the authorization to practice in the given state. See appendix for a list of valid values.
-	AuthorizationToPracticeDescription string `json:"AuthorizationToPracticeDescription"` // Required 50 Description indicating the authorization to practice in the given state. See appendix for a list of valid values.
-	AuthorizationToPracticeNarrative   string `json:"AuthorizationToPracticeNarrative"`   // Required 5000 Detailed information regarding the authorization to practice in the given state.
+	StateAbbreviation                  string                      `json:"StateAbbreviation"`                  // Required 2 State. See appendix for a list of valid values.
+	StateDescription                   string                      `json:"StateDescription"`                   // Required 50 State. See appendix for a list of valid values.
+	AuthorizationToPracticeCode        AuthorizationToPracticeCode `json:"AuthorizationToPracticeCode"`        // Required 1 Code indicating the authorization to practice in the given state. See appendix for a list of valid values.
+	AuthorizationToPracticeDescription string                      `json:"AuthorizationToPracticeDescription"` // Required 50 Description indicating the authorization to practice in the given state. See appendix for a list of valid values.
+	AuthorizationToPracticeNarrative   string                      `json:"AuthorizationToPracticeNarrative"`   // Required 5000 Detailed information regarding the authorization to practice in the given state.
 }
@@ -0,0 +1,263 @@
+package nursys
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// NurseLookupChangeKind tells what changed in a NurseLookupChange.
+type NurseLookupChangeKind string
+
+// Nurse Lookup change kinds
+const (
+	ChangeLicenseAdded          NurseLookupChangeKind = "LicenseAdded"
+	ChangeLicenseRemoved        NurseLookupChangeKind = "LicenseRemoved"
+	ChangeLicenseField          NurseLookupChangeKind = "LicenseField" // Active, LicenseStatus, LicenseExpirationDate or CompactStatus changed.
+	ChangeDisciplineAdded       NurseLookupChangeKind = "DisciplineAdded"
+	ChangeRevisionReportAdded   NurseLookupChangeKind = "RevisionReportAdded"
+	ChangeNotificationAdded     NurseLookupChangeKind = "NotificationAdded"
+	ChangeAuthorizationAdded    NurseLookupChangeKind = "AuthorizationAdded"
+	ChangeAuthorizationRemoved  NurseLookupChangeKind = "AuthorizationRemoved"
+	ChangeAuthorizationModified NurseLookupChangeKind = "AuthorizationModified"
+)
+
+// NurseLookupChange is a single difference between two Nurse Lookup responses for the same nurse.
+// Only the fields relevant to Kind are set.
+type NurseLookupChange struct {
+	Kind NurseLookupChangeKind
+
+	// License the change applies to. Set for all license, discipline, revision report and notification changes.
+	Jurisdiction  Jurisdiction
+	LicenseType   LicenseType
+	LicenseNumber string
+
+	Field    string // For ChangeLicenseField, the name of the field that changed.
+	OldValue string // For ChangeLicenseField and ChangeAuthorizationModified, the value before the change.
+	NewValue string // For ChangeLicenseField and ChangeAuthorizationModified, the value after the change.
+
+	License        *NurseLookupLicense        // For ChangeLicenseAdded and ChangeLicenseRemoved.
+	Discipline     *NurseLookupDiscipline     // For ChangeDisciplineAdded and ChangeRevisionReportAdded, the discipline in the newer response.
+	RevisionReport *NurseLookupRevisionReport // For ChangeRevisionReportAdded.
+	Notification   *NurseLookupNotification   // For ChangeNotificationAdded.
+
+	// Authorization to practice the change applies to. Set for authorization changes.
+	Family        LicenseType              // LicenseTypeRN or LicenseTypePN.
+	State         string                   // Two letter state abbreviation.
+	Authorization *AuthorizationToPractice // The newer entry, or the older one for ChangeAuthorizationRemoved.
+}
+
+// String returns a one line description of the change.
+func (c NurseLookupChange) String() string {
+	license := strings.TrimSpace(strings.Join([]string{string(c.Jurisdiction), string(c.LicenseType), c.LicenseNumber}, " "))
+	switch c.Kind {
+	case ChangeLicenseAdded:
+		return "license added: " + license
+	case ChangeLicenseRemoved:
+		return "license removed: " + license
+	case ChangeLicenseField:
+		return fmt.Sprintf("%s: %s changed from %q to %q", license, c.Field, c.OldValue, c.NewValue)
+	case ChangeDisciplineAdded:
+		return fmt.Sprintf("%s: discipline added by %s on %s", license, c.Discipline.JurisdictionAbbreviation,
+			time.Time(c.Discipline.DateActionWasTaken).Format(time.DateOnly))
+	case ChangeRevisionReportAdded:
+		return fmt.Sprintf("%s: revision report added on %s to discipline of %s", license,
+			time.Time(c.RevisionReport.RevisionReportDate).Format(time.DateOnly), time.Time(c.Discipline.DateActionWasTaken).Format(time.DateOnly))
+	case ChangeNotificationAdded:
+		return fmt.Sprintf("%s: notification added by %s on %s", license, c.Notification.JurisdictionAbbreviation,
+			time.Time(c.Notification.NotificationDate).Format(time.DateOnly))
+	case ChangeAuthorizationAdded:
+		return fmt.Sprintf("%s authorization to practice in %s added", c.Family, c.State)
+	case ChangeAuthorizationRemoved:
+		return fmt.Sprintf("%s authorization to practice in %s removed", c.Family, c.State)
+	case ChangeAuthorizationModified:
+		return fmt.Sprintf("%s authorization to practice in %s changed from %q to %q", c.Family, c.State, c.OldValue, c.NewValue)
+	}
+	return string(c.Kind)
+}
+
+// DiffNurseLookup compares an older and a newer Nurse Lookup response for the same nurse and returns what
+// changed, ordered by license (jurisdiction, type, number) and then by authorization family and state.
+//
+// Licenses are matched by jurisdiction, license type and license number. Disciplines are matched by
+// jurisdiction, date, whether they were taken against the privilege to practice, and the codes of their
+// initial actions and basis for actions, revision reports by date, and notifications by jurisdiction, date
+// and message. Removed disciplines, revision reports and notifications are not reported.
+func DiffNurseLookup(older, newer NurseLookupResponse) []NurseLookupChange {
+	var changes []NurseLookupChange
+
+	before := indexLicenses(older.NurseLookupLicenses)
+	after := indexLicenses(newer.NurseLookupLicenses)
+	keys := sortedKeys(after)
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		prev, hadPrev := before[key]
+		next, hasNext := after[key]
+		switch {
+		case !hadPrev:
+			changes = append(changes, licenseChange(ChangeLicenseAdded, next))
+			changes[len(changes)-1].License = &next
+		case !hasNext:
+			changes = append(changes, licenseChange(ChangeLicenseRemoved, prev))
+			changes[len(changes)-1].License = &prev
+		default:
+			changes = append(changes, diffLicense(prev, next)...)
+		}
+	}
+
+	for _, family := range []LicenseType{LicenseTypeRN, LicenseTypePN} {
+		changes = append(changes, diffAuthorizations(family, older.authorizationsToPractice(family), newer.authorizationsToPractice(family))...)
+	}
+	return changes
+}
+
+func indexLicenses(licenses []NurseLookupLicense) map[string]NurseLookupLicense {
+	index := make(map[string]NurseLookupLicense, len(licenses))
+	for _, l := range licenses {
+		index[strings.Join([]string{
+			strings.ToUpper(strings.TrimSpace(string(l.JurisdictionAbbreviation))),
+			strings.ToUpper(strings.TrimSpace(string(l.LicenseType))),
+			strings.TrimSpace(l.LicenseNumber),
+		}, "|")] = l
+	}
+	return index
+}
+
+func licenseChange(kind NurseLookupChangeKind, l NurseLookupLicense) NurseLookupChange {
+	return NurseLookupChange{Kind: kind, Jurisdiction: l.JurisdictionAbbreviation, LicenseType: l.LicenseType, LicenseNumber: l.LicenseNumber}
+}
+
+// diffLicense returns the changes between two versions of the same license.
+func diffLicense(prev, next NurseLookupLicense) []NurseLookupChange {
+	var changes []NurseLookupChange
+	field := func(name, was, is string) {
+		if strings.TrimSpace(was) != strings.TrimSpace(is) {
+			c := licenseChange(ChangeLicenseField, next)
+			c.Field, c.OldValue, c.NewValue = name, was, is
+			changes = append(changes, c)
+		}
+	}
+	field("Active", prev.Active, next.Active)
+	field("LicenseStatus", prev.LicenseStatus, next.LicenseStatus)
+	prevExp, prevOK := prev.ExpirationDate()
+	nextExp, nextOK := next.ExpirationDate()
+	if !prevOK || !nextOK || !prevExp.Equal(nextExp) {
+		field("LicenseExpirationDate", prev.LicenseExpirationDate, next.LicenseExpirationDate)
+	}
+	field("CompactStatus", prev.CompactStatus, next.CompactStatus)
+
+	disciplines := make(map[string]NurseLookupDiscipline, len(prev.NurseLookupDisciplines))
+	for _, d := range prev.NurseLookupDisciplines {
+		disciplines[disciplineKey(d)] = d
+	}
+	for i := range next.NurseLookupDisciplines {
+		d := &next.NurseLookupDisciplines[i]
+		old, ok := disciplines[disciplineKey(*d)]
+		if !ok {
+			c := licenseChange(ChangeDisciplineAdded, next)
+			c.Discipline = d
+			changes = append(changes, c)
+			continue
+		}
+		reports := make(map[time.Time]bool, len(old.NurseLookupRevisionReports))
+		for _, rr := range old.NurseLookupRevisionReports {
+			reports[time.Time(rr.RevisionReportDate).UTC()] = true
+		}
+		for j := range d.NurseLookupRevisionReports {
+			rr := &d.NurseLookupRevisionReports[j]
+			if !reports[time.Time(rr.RevisionReportDate).UTC()] {
+				c := licenseChange(ChangeRevisionReportAdded, next)
+				c.Discipline, c.RevisionReport = d, rr
+				changes = append(changes, c)
+			}
+		}
+	}
+
+	notifications := make(map[string]bool, len(prev.NurseLookupNotifications))
+	for _, n := range prev.NurseLookupNotifications {
+		notifications[notificationKey(n)] = true
+	}
+	for i := range next.NurseLookupNotifications {
+		n := &next.NurseLookupNotifications[i]
+		if !notifications[notificationKey(*n)] {
+			c := licenseChange(ChangeNotificationAdded, next)
+			c.Notification = n
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// disciplineKey identifies a discipline by its board, date, privilege to practice flag, and the codes of its
+// initial actions and bases for action, which revision reports leave unchanged.
+func disciplineKey(d NurseLookupDiscipline) string {
+	var actions, bases []string
+	for _, a := range d.NurseLookupInitialActions {
+		actions = append(actions, strings.TrimSpace(string(a.ActionCode)))
+	}
+	for _, b := range d.NurseLookupBasisForActions {
+		bases = append(bases, strings.TrimSpace(string(b.BasisForActionCode)))
+	}
+	sort.Strings(actions)
+	sort.Strings(bases)
+	return fmt.Sprintf("%s|%s|%t|%s|%s", strings.ToUpper(string(d.JurisdictionAbbreviation)),
+		time.Time(d.DateActionWasTaken).UTC().Format(time.RFC3339), d.AgainstPrivilegeToPracticeFlag,
+		strings.Join(actions, ","), strings.Join(bases, ","))
+}
+
+func notificationKey(n NurseLookupNotification) string {
+	return strings.Join([]string{strings.ToUpper(string(n.JurisdictionAbbreviation)),
+		time.Time(n.NotificationDate).UTC().Format(time.RFC3339), strings.TrimSpace(n.NotificationMessage)}, "|")
+}
+
+// diffAuthorizations returns the changes between two authorization to practice lists of the same family.
+// An entry is modified when its code or description changes.
+func diffAuthorizations(family LicenseType, prev, next []AuthorizationToPractice) []NurseLookupChange {
+	index := func(list []AuthorizationToPractice) map[string]*AuthorizationToPractice {
+		m := make(map[string]*AuthorizationToPractice, len(list))
+		for i := range list {
+			m[strings.ToUpper(strings.TrimSpace(list[i].StateAbbreviation))] = &list[i]
+		}
+		return m
+	}
+	before, after := index(prev), index(next)
+	states := sortedKeys(after)
+	for state := range before {
+		if _, ok := after[state]; !ok {
+			states = append(states, state)
+		}
+	}
+	sort.Strings(states)
+
+	var changes []NurseLookupChange
+	for _, state := range states {
+		was, is := before[state], after[state]
+		c := NurseLookupChange{Family: family, State: state, Authorization: is}
+		switch {
+		case was == nil:
+			c.Kind = ChangeAuthorizationAdded
+		case is == nil:
+			c.Kind, c.Authorization = ChangeAuthorizationRemoved, was
+		case was.AuthorizationToPracticeCode != is.AuthorizationToPracticeCode ||
+			was.AuthorizationToPracticeDescription != is.AuthorizationToPracticeDescription:
+			c.Kind = ChangeAuthorizationModified
+			c.OldValue, c.NewValue = authorizationValue(*was), authorizationValue(*is)
+		default:
+			continue
+		}
+		changes = append(changes, c)
+	}
+	return changes
+}
+
+// authorizationValue describes an authorization to practice by its code and description, e.g. "M Multistate license".
+func authorizationValue(a AuthorizationToPractice) string {
+	return strings.TrimSpace(strings.TrimSpace(string(a.AuthorizationToPracticeCode)) + " " + strings.TrimSpace(a.AuthorizationToPracticeDescription))
+}
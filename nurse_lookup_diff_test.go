@@ -0,0 +1,113 @@
+package nursys_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/connectRN/go-nursys"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DiffNurseLookup(t *testing.T) {
+	assert := assert.New(t)
+	date := func(y int, m time.Month, d int) nursys.Time {
+		return nursys.Time(time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+	}
+
+	discipline := nursys.NurseLookupDiscipline{JurisdictionAbbreviation: "TX", DateActionWasTaken: date(2022, 1, 10)}
+	older := nursys.NurseLookupResponse{
+		NurseLookupLicenses: []nursys.NurseLookupLicense{
+			{JurisdictionAbbreviation: "TX", LicenseType: nursys.LicenseTypeRN, LicenseNumber: "1", LicenseStatus: "Active",
+				LicenseExpirationDate: "2024-06-30", NurseLookupDisciplines: []nursys.NurseLookupDiscipline{discipline}},
+			{JurisdictionAbbreviation: "FL", LicenseType: nursys.LicenseTypeRN, LicenseNumber: "2"},
+		},
+		NurseLookupRNAuthorizationsToPractice: []nursys.AuthorizationToPractice{
+			{StateAbbreviation: "TX", AuthorizationToPracticeDescription: "Multistate license"},
+			{StateAbbreviation: "FL", AuthorizationToPracticeDescription: "Single state license"},
+		},
+	}
+
+	revised := discipline
+	revised.NurseLookupRevisionReports = []nursys.NurseLookupRevisionReport{{RevisionReportDate: date(2024, 2, 1)}}
+	newer := nursys.NurseLookupResponse{
+		NurseLookupLicenses: []nursys.NurseLookupLicense{
+			{JurisdictionAbbreviation: "TX", LicenseType: nursys.LicenseTypeRN, LicenseNumber: "1", LicenseStatus: "Encumbered",
+				LicenseExpirationDate: "06/30/2024", // Same date, different format
+				NurseLookupDisciplines: []nursys.NurseLookupDiscipline{
+					revised,
+					{JurisdictionAbbreviation: "TX", DateActionWasTaken: date(2024, 3, 5)},
+				},
+				NurseLookupNotifications: []nursys.NurseLookupNotification{
+					{JurisdictionAbbreviation: "TX", NotificationDate: date(2024, 3, 6), NotificationMessage: "Under investigation"},
+				}},
+			{JurisdictionAbbreviation: "NY", LicenseType: nursys.LicenseTypePN, LicenseNumber: "3"},
+		},
+		NurseLookupRNAuthorizationsToPractice: []nursys.AuthorizationToPractice{
+			{StateAbbreviation: "TX", AuthorizationToPracticeDescription: "Single state license"},
+			{StateAbbreviation: "CO", AuthorizationToPracticeDescription: "Multistate privilege"},
+		},
+	}
+
+	changes := nursys.DiffNurseLookup(older, newer)
+	var lines []string
+	for _, c := range changes {
+		lines = append(lines, c.String())
+	}
+	assert.Equal([]string{
+		"license removed: FL RN 2",
+		"license added: NY PN 3",
+		`TX RN 1: LicenseStatus changed from "Active" to "Encumbered"`,
+		"TX RN 1: revision report added on 2024-02-01 to discipline of 2022-01-10",
+		"TX RN 1: discipline added by TX on 2024-03-05",
+		"TX RN 1: notification added by TX on 2024-03-06",
+		"RN authorization to practice in CO added",
+		"RN authorization to practice in FL removed",
+		`RN authorization to practice in TX changed from "Multistate license" to "Single state license"`,
+	}, lines)
+
+	require.Len(t, changes, 9)
+	assert.Equal(nursys.ChangeLicenseField, changes[2].Kind)
+	assert.Equal("LicenseStatus", changes[2].Field)
+	assert.Equal("3", changes[1].License.LicenseNumber)
+	assert.Equal("Single state license", changes[7].Authorization.AuthorizationToPracticeDescription)
+	assert.Equal("Multistate license", changes[8].OldValue)
+
+	assert.Empty(nursys.DiffNurseLookup(newer, newer))
+}
+
+func Test_DiffNurseLookup_Keys(t *testing.T) {
+	assert := assert.New(t)
+	day := nursys.Time(time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC))
+	discipline := func(action nursys.NPDBActionCode, basis nursys.NPDBBasisCode) nursys.NurseLookupDiscipline {
+		return nursys.NurseLookupDiscipline{
+			JurisdictionAbbreviation:   "TX",
+			DateActionWasTaken:         day,
+			NurseLookupInitialActions:  []nursys.NurseLookupAction{{ActionCode: action}},
+			NurseLookupBasisForActions: []nursys.NurseLookupBasisForAction{{BasisForActionCode: basis}},
+		}
+	}
+	lookup := func(auth nursys.AuthorizationToPractice, disciplines ...nursys.NurseLookupDiscipline) nursys.NurseLookupResponse {
+		return nursys.NurseLookupResponse{
+			NurseLookupLicenses: []nursys.NurseLookupLicense{{JurisdictionAbbreviation: "TX", LicenseType: nursys.LicenseTypeRN,
+				LicenseNumber: "1", NurseLookupDisciplines: disciplines}},
+			NurseLookupRNAuthorizationsToPractice: []nursys.AuthorizationToPractice{auth},
+		}
+	}
+	auth := nursys.AuthorizationToPractice{StateAbbreviation: "TX", AuthorizationToPracticeCode: "A", AuthorizationToPracticeDescription: "Authorized"}
+	recoded := auth
+	recoded.AuthorizationToPracticeCode = "B"
+
+	older := lookup(auth, discipline("1", "A1"))
+	newer := lookup(recoded, discipline("1", "A1"), discipline("1", "B1"), discipline("2", "A1"))
+	var lines []string
+	for _, c := range nursys.DiffNurseLookup(older, newer) {
+		lines = append(lines, c.String())
+	}
+	assert.Equal([]string{
+		"TX RN 1: discipline added by TX on 2022-01-10",
+		"TX RN 1: discipline added by TX on 2022-01-10",
+		`RN authorization to practice in TX changed from "A Authorized" to "B Authorized"`,
+	}, lines)
+}
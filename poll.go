@@ -0,0 +1,75 @@
+package nursys
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultPollInterval is the delay between result requests used by the Poll helpers when no interval is given.
+const DefaultPollInterval = 30 * time.Second
+
+// PollManageNurseListResult calls GetManageNurseListResult every interval until the API reports that
+// processing of the transaction is complete, an error occurs, or ctx is done.
+func PollManageNurseListResult(ctx context.Context, c Client, txID string, interval time.Duration) (ManageNurseListRetrieveResponseMessage, error) {
+	return pollResult(ctx, c, EndpointGetManageNurseListResult, txID, interval, func(ctx context.Context) (ManageNurseListRetrieveResponseMessage, bool, error) {
+		resp, err := c.GetManageNurseListResult(ctx, txID)
+		return resp, resp.ProcessingCompleteFlag, err
+	})
+}
+
+// PollNurseLookupResult calls GetNurseLookupResult every interval until the API reports that
+// processing of the transaction is complete, an error occurs, or ctx is done.
+func PollNurseLookupResult(ctx context.Context, c Client, txID string, interval time.Duration) (NurseLookupRetrieveResponseMessage, error) {
+	return pollResult(ctx, c, EndpointGetNurseLookupResult, txID, interval, func(ctx context.Context) (NurseLookupRetrieveResponseMessage, bool, error) {
+		resp, err := c.GetNurseLookupResult(ctx, txID)
+		return resp, resp.ProcessingCompleteFlag, err
+	})
+}
+
+// PollNotificationLookupResult calls GetNotificationLookupResult every interval until the API reports that
+// processing of the transaction is complete, an error occurs, or ctx is done.
+func PollNotificationLookupResult(ctx context.Context, c Client, txID string, interval time.Duration) (NotificationLookupRetrieveResponseMessage, error) {
+	return pollResult(ctx, c, EndpointGetNotificationLookupResult, txID, interval, func(ctx context.Context) (NotificationLookupRetrieveResponseMessage, bool, error) {
+		resp, err := c.GetNotificationLookupResult(ctx, txID)
+		return resp, resp.ProcessingCompleteFlag, err
+	})
+}
+
+// pollResult polls the result of transaction txID from endpoint within an operation span, and reports the
+// number of result requests made to the metrics of c.
+func pollResult[T any](ctx context.Context, c Client, endpoint, txID string, interval time.Duration, fetch func(ctx context.Context) (T, bool, error)) (T, error) {
+	ctx, span := StartOperation(ctx, c, "nursys.Poll", Attribute{AttrEndpoint, endpoint}, Attribute{AttrTransactionID, txID})
+	defer span.End()
+	iterations := 0
+	resp, err := poll(ctx, interval, func(ctx context.Context) (T, bool, error) {
+		iterations++
+		return fetch(ctx)
+	})
+	span.SetAttributes(Attribute{AttrPollIterations, iterations})
+	if err != nil {
+		span.RecordError(err)
+	} else {
+		recordPoll(c, endpoint, iterations)
+	}
+	return resp, err
+}
+
+// poll calls fetch immediately and then every interval until it reports done or fails.
+func poll[T any](ctx context.Context, interval time.Duration, fetch func(ctx context.Context) (T, bool, error)) (T, error) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	for {
+		resp, done, err := fetch(ctx)
+		if err != nil || done {
+			return resp, err
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
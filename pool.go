@@ -0,0 +1,187 @@
+package nursys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// ErrUnknownInstitution is returned, wrapped, by a CredentialsProvider that has no credentials for an institution.
+var ErrUnknownInstitution = errors.New("nursys: unknown institution")
+
+// Credentials are the Nursys API username and password of an institution.
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// CredentialsProvider loads the credentials of institutions, for example from a secrets store.
+// Implementations must be safe for concurrent use.
+type CredentialsProvider interface {
+	Credentials(ctx context.Context, institutionID string) (Credentials, error)
+}
+
+// CredentialsProviderFunc adapts a function to a CredentialsProvider.
+type CredentialsProviderFunc func(ctx context.Context, institutionID string) (Credentials, error)
+
+// Credentials implements CredentialsProvider.
+func (f CredentialsProviderFunc) Credentials(ctx context.Context, institutionID string) (Credentials, error) {
+	return f(ctx, institutionID)
+}
+
+// StaticCredentials is a CredentialsProvider with fixed credentials by institution ID.
+type StaticCredentials map[string]Credentials
+
+// Credentials implements CredentialsProvider.
+func (s StaticCredentials) Credentials(_ context.Context, institutionID string) (Credentials, error) {
+	creds, ok := s[institutionID]
+	if !ok {
+		return Credentials{}, fmt.Errorf("%w: %q", ErrUnknownInstitution, institutionID)
+	}
+	return creds, nil
+}
+
+// ClientPool manages a Client per institution, for services acting on behalf of several institutions with
+// their own credentials. Clients are created on first use with the credentials loaded from the provider.
+//
+// All clients share one http.Client, so connections are reused across institutions, and the options given
+// to NewClientPool: a RateLimiter or CircuitBreaker given with WithRateLimit or WithCircuitBreaker limits
+// the institutions together. Metrics reported to a recorder given with WithMetrics carry an additional
+// "institution" label.
+//
+// A ClientPool is safe for concurrent use.
+type ClientPool struct {
+	baseURL     string
+	credentials CredentialsProvider
+	options     []ClientOption
+	httpClient  *http.Client
+
+	mu      sync.Mutex
+	clients map[string]Client
+}
+
+// NewClientPool creates a ClientPool. It returns an error if baseURL or an option is invalid.
+//
+//	pool, err := nursys.NewClientPool(nursys.EnvironmentProduction.BaseURL(), provider,
+//		nursys.WithRateLimit(limiter), nursys.WithMetrics(metrics))
+func NewClientPool(baseURL string, credentials CredentialsProvider, options ...ClientOption) (*ClientPool, error) {
+	template, err := NewClient(baseURL, "", "", options...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientPool{
+		baseURL:     baseURL,
+		credentials: credentials,
+		options:     options,
+		httpClient:  template.(*nsHTTPClient).httpClient,
+		clients:     make(map[string]Client),
+	}, nil
+}
+
+// Client returns the client of an institution, loading its credentials on first use.
+func (p *ClientPool) Client(ctx context.Context, institutionID string) (Client, error) {
+	p.mu.Lock()
+	c, ok := p.clients[institutionID]
+	p.mu.Unlock()
+	if ok {
+		return c, nil
+	}
+
+	// The provider may be slow, so it is called without holding the lock.
+	creds, err := p.credentials.Credentials(ctx, institutionID)
+	if err != nil {
+		return nil, fmt.Errorf("nursys: loading credentials of institution %q: %w", institutionID, err)
+	}
+	options := append(p.options[:len(p.options):len(p.options)], WithHTTPClient(p.httpClient), withInstitution(institutionID))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if c, ok := p.clients[institutionID]; ok {
+		return c, nil
+	}
+	c = New(p.baseURL, creds.Username, creds.Password, options...)
+	p.clients[institutionID] = c
+	return c, nil
+}
+
+// Invalidate drops the client of an institution, so that its credentials are loaded again on next use,
+// for example after they were changed with ChangePassword.
+func (p *ClientPool) Invalidate(institutionID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.clients, institutionID)
+}
+
+// Institutions returns the IDs of the institutions with a client, sorted.
+func (p *ClientPool) Institutions() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return sortedKeys(p.clients)
+}
+
+// ManageNurseList submits request with the client of an institution.
+func (p *ClientPool) ManageNurseList(ctx context.Context, institutionID string, request ManageNurseListSubmitRequestMessage) (ManageNurseListSubmitResponseMessage, error) {
+	c, err := p.Client(ctx, institutionID)
+	if err != nil {
+		return ManageNurseListSubmitResponseMessage{}, err
+	}
+	return c.ManageNurseList(ctx, request)
+}
+
+// GetManageNurseListResult retrieves the result of a transaction submitted with ManageNurseList for an institution.
+func (p *ClientPool) GetManageNurseListResult(ctx context.Context, institutionID, txID string) (ManageNurseListRetrieveResponseMessage, error) {
+	c, err := p.Client(ctx, institutionID)
+	if err != nil {
+		return ManageNurseListRetrieveResponseMessage{}, err
+	}
+	return c.GetManageNurseListResult(ctx, txID)
+}
+
+// NotificationLookup submits request with the client of an institution.
+func (p *ClientPool) NotificationLookup(ctx context.Context, institutionID string, request NotificationLookupSubmitRequestMessage) (NotificationLookupSubmitResponseMessage, error) {
+	c, err := p.Client(ctx, institutionID)
+	if err != nil {
+		return NotificationLookupSubmitResponseMessage{}, err
+	}
+	return c.NotificationLookup(ctx, request)
+}
+
+// GetNotificationLookupResult retrieves the result of a transaction submitted with NotificationLookup for an institution.
+func (p *ClientPool) GetNotificationLookupResult(ctx context.Context, institutionID, txID string) (NotificationLookupRetrieveResponseMessage, error) {
+	c, err := p.Client(ctx, institutionID)
+	if err != nil {
+		return NotificationLookupRetrieveResponseMessage{}, err
+	}
+	return c.GetNotificationLookupResult(ctx, txID)
+}
+
+// withInstitution adds the institution label to the metrics of a pool client.
+func withInstitution(institutionID string) ClientOption {
+	return func(c *nsHTTPClient) {
+		if c.metrics != nil {
+			c.metrics = institutionRecorder{c.metrics, Label{LabelInstitution, institutionID}}
+		}
+	}
+}
+
+// institutionRecorder is a MetricsRecorder that adds the institution label to every metric.
+type institutionRecorder struct {
+	MetricsRecorder
+	institution Label
+}
+
+func (r institutionRecorder) Count(name string, delta float64, labels ...Label) {
+	r.MetricsRecorder.Count(name, delta, r.labels(labels)...)
+}
+
+func (r institutionRecorder) Observe(name string, value float64, labels ...Label) {
+	r.MetricsRecorder.Observe(name, value, r.labels(labels)...)
+}
+
+// labels returns a copy of labels with the institution label added, leaving the caller's array untouched.
+func (r institutionRecorder) labels(labels []Label) []Label {
+	l := make([]Label, 0, len(labels)+1)
+	return append(append(l, labels...), r.institution)
+}
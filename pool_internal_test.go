@@ -0,0 +1,37 @@
+package nursys
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type labelsRecorder struct {
+	labels [][]Label
+}
+
+func (r *labelsRecorder) Count(_ string, _ float64, labels ...Label) {
+	r.labels = append(r.labels, labels)
+}
+
+func (r *labelsRecorder) Observe(_ string, _ float64, labels ...Label) {
+	r.labels = append(r.labels, labels)
+}
+
+func Test_InstitutionRecorder_LabelsCopied(t *testing.T) {
+	inner := &labelsRecorder{}
+	r := institutionRecorder{inner, Label{LabelInstitution, "inst-a"}}
+
+	// The spare capacity of the caller's labels must not be written to.
+	labels := make([]Label, 1, 2)
+	labels[0] = Label{LabelEndpoint, "NurseLookup"}
+	spare := labels[:2]
+	spare[1] = Label{"other", "x"}
+	r.Count(MetricRequests, 1, labels...)
+	r.Observe(MetricRequestDuration, 1, labels...)
+
+	assert.Equal(t, Label{"other", "x"}, spare[1])
+	for _, l := range inner.labels {
+		assert.Equal(t, []Label{{LabelEndpoint, "NurseLookup"}, {LabelInstitution, "inst-a"}}, l)
+	}
+}
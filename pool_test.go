@@ -0,0 +1,101 @@
+package nursys_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/connectRN/go-nursys"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ClientPool(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+
+	var usernames []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		usernames = append(usernames, req.Header.Get(nursys.HeaderUsername))
+		rw.Write(submitResponseJSON)
+	}))
+	t.Cleanup(server.Close)
+
+	loads := 0
+	static := nursys.StaticCredentials{
+		"inst-a": {Username: "alpha", Password: "a!"},
+		"inst-b": {Username: "bravo", Password: "b!"},
+	}
+	provider := nursys.CredentialsProviderFunc(func(ctx context.Context, institutionID string) (nursys.Credentials, error) {
+		loads++
+		return static.Credentials(ctx, institutionID)
+	})
+	metrics := nursys.NewPrometheusRecorder(nursys.PrometheusRecorderConfig{})
+	pool, err := nursys.NewClientPool(server.URL, provider, nursys.WithMetrics(metrics))
+	require.NoError(t, err)
+
+	request := nursys.ManageNurseListSubmitRequestMessage{ManageNurseListRequests: []nursys.ManageNurseListRequest{testNurse("1")}}
+	_, err = pool.ManageNurseList(ctx, "inst-a", request)
+	require.NoError(t, err)
+	_, err = pool.ManageNurseList(ctx, "inst-b", request)
+	require.NoError(t, err)
+	_, err = pool.NotificationLookup(ctx, "inst-a", nursys.NotificationLookupSubmitRequestMessage{})
+	require.NoError(t, err)
+	_, err = pool.GetNotificationLookupResult(ctx, "inst-b", "tx")
+	require.NoError(t, err)
+
+	assert.Equal([]string{"alpha", "bravo", "alpha", "bravo"}, usernames)
+	assert.Equal(2, loads)
+	assert.Equal([]string{"inst-a", "inst-b"}, pool.Institutions())
+
+	a1, err := pool.Client(ctx, "inst-a")
+	require.NoError(t, err)
+	a2, err := pool.Client(ctx, "inst-a")
+	require.NoError(t, err)
+	assert.Same(a1, a2)
+
+	pool.Invalidate("inst-a")
+	assert.Equal([]string{"inst-b"}, pool.Institutions())
+	_, err = pool.Client(ctx, "inst-a")
+	require.NoError(t, err)
+	assert.Equal(3, loads)
+
+	_, err = pool.ManageNurseList(ctx, "inst-c", request)
+	assert.ErrorIs(err, nursys.ErrUnknownInstitution)
+
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+	assert.Contains(body, `nursys_requests_total{endpoint="ManageNurseList",institution="inst-a",status="200"} 1`)
+	assert.Contains(body, `nursys_requests_total{endpoint="ManageNurseList",institution="inst-b",status="200"} 1`)
+	assert.Contains(body, `nursys_requests_total{endpoint="NotificationLookup",institution="inst-a",status="200"} 1`)
+}
+
+func Test_ClientPoolSharedLimits(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	breaker := nursys.NewCircuitBreaker(nursys.CircuitBreakerConfig{FailureThreshold: 2})
+	pool, err := nursys.NewClientPool(server.URL, nursys.StaticCredentials{"inst-a": {}, "inst-b": {}}, nursys.WithCircuitBreaker(breaker))
+	require.NoError(t, err)
+
+	// Failures of two institutions open the shared circuit for both.
+	_, err = pool.ManageNurseList(ctx, "inst-a", nursys.ManageNurseListSubmitRequestMessage{})
+	require.Error(t, err)
+	_, err = pool.ManageNurseList(ctx, "inst-b", nursys.ManageNurseListSubmitRequestMessage{})
+	require.Error(t, err)
+	_, err = pool.ManageNurseList(ctx, "inst-a", nursys.ManageNurseListSubmitRequestMessage{})
+	assert.ErrorIs(t, err, nursys.ErrCircuitOpen)
+}
+
+func Test_ClientPoolInvalidConfig(t *testing.T) {
+	_, err := nursys.NewClientPool("api.nursys.test", nursys.StaticCredentials{})
+	assert.ErrorContains(t, err, "invalid base URL")
+	_, err = nursys.NewClientPool("https://api.nursys.test/", nursys.StaticCredentials{}, nursys.WithProxy("proxy:3128"))
+	assert.ErrorContains(t, err, "invalid proxy URL")
+}
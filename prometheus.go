@@ -0,0 +1,191 @@
+package nursys
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// defaultPrometheusBuckets are the histogram bucket upper bounds used by PrometheusRecorder, by metric name.
+var defaultPrometheusBuckets = map[string][]float64{
+	MetricRequestDuration: {0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	MetricPollIterations:  {1, 2, 3, 5, 10, 20, 50, 100},
+	MetricBatchSize:       {1, 5, 10, 25, 50, 100, 250, 500, 1000},
+}
+
+// DefaultPrometheusBuckets returns a copy of the histogram bucket upper bounds used by PrometheusRecorder,
+// by metric name, for callers that want to change some of them in PrometheusRecorderConfig.
+func DefaultPrometheusBuckets() map[string][]float64 {
+	return clonePrometheusBuckets(defaultPrometheusBuckets)
+}
+
+// PrometheusRecorderConfig configures a PrometheusRecorder.
+type PrometheusRecorderConfig struct {
+	// Buckets are the histogram bucket upper bounds by metric name, in increasing order. Histograms not
+	// listed use the MetricRequestDuration buckets. DefaultPrometheusBuckets() if nil.
+	Buckets map[string][]float64
+}
+
+// PrometheusRecorder is a MetricsRecorder that keeps metrics in memory and serves them in the Prometheus
+// text exposition format. It is safe for concurrent use.
+//
+// For example:
+//
+//	metrics := nursys.NewPrometheusRecorder(nursys.PrometheusRecorderConfig{})
+//	client := nursys.New(baseURL, username, password, nursys.WithMetrics(metrics))
+//	http.Handle("/metrics", metrics)
+type PrometheusRecorder struct {
+	buckets map[string][]float64
+
+	mu         sync.Mutex
+	counters   map[string]map[string]float64              // Metric name, then formatted labels.
+	histograms map[string]map[string]*prometheusHistogram // Metric name, then formatted labels.
+}
+
+type prometheusHistogram struct {
+	bounds []float64
+	counts []uint64 // Per bucket, not cumulative.
+	count  uint64
+	sum    float64
+}
+
+// NewPrometheusRecorder creates an empty PrometheusRecorder. The buckets of cfg are copied, so changing
+// them afterwards does not affect the recorder.
+func NewPrometheusRecorder(cfg PrometheusRecorderConfig) *PrometheusRecorder {
+	if cfg.Buckets == nil {
+		cfg.Buckets = defaultPrometheusBuckets
+	}
+	buckets := clonePrometheusBuckets(cfg.Buckets)
+	if _, ok := buckets[MetricRequestDuration]; !ok {
+		buckets[MetricRequestDuration] = slices.Clone(defaultPrometheusBuckets[MetricRequestDuration])
+	}
+	return &PrometheusRecorder{
+		buckets:    buckets,
+		counters:   make(map[string]map[string]float64),
+		histograms: make(map[string]map[string]*prometheusHistogram),
+	}
+}
+
+// Count implements MetricsRecorder.
+func (p *PrometheusRecorder) Count(name string, delta float64, labels ...Label) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	series, ok := p.counters[name]
+	if !ok {
+		series = make(map[string]float64)
+		p.counters[name] = series
+	}
+	series[formatPrometheusLabels(labels)] += delta
+}
+
+// Observe implements MetricsRecorder.
+func (p *PrometheusRecorder) Observe(name string, value float64, labels ...Label) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	series, ok := p.histograms[name]
+	if !ok {
+		series = make(map[string]*prometheusHistogram)
+		p.histograms[name] = series
+	}
+	key := formatPrometheusLabels(labels)
+	h, ok := series[key]
+	if !ok {
+		bounds, ok := p.buckets[name]
+		if !ok {
+			bounds = p.buckets[MetricRequestDuration]
+		}
+		h = &prometheusHistogram{bounds: bounds, counts: make([]uint64, len(bounds))}
+		series[key] = h
+	}
+	if i := sort.SearchFloat64s(h.bounds, value); i < len(h.bounds) {
+		h.counts[i]++
+	}
+	h.count++
+	h.sum += value
+}
+
+// ServeHTTP serves the metrics in the Prometheus text exposition format.
+func (p *PrometheusRecorder) ServeHTTP(rw http.ResponseWriter, _ *http.Request) {
+	rw.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	p.WriteTo(rw)
+}
+
+// WriteTo writes the metrics to w in the Prometheus text exposition format, ordered by metric name and labels.
+func (p *PrometheusRecorder) WriteTo(w io.Writer) (int64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var b strings.Builder
+	for _, name := range sortedKeys(p.counters) {
+		fmt.Fprintf(&b, "# TYPE %s counter\n", name)
+		series := p.counters[name]
+		for _, labels := range sortedKeys(series) {
+			fmt.Fprintf(&b, "%s%s %s\n", name, labels, formatPrometheusValue(series[labels]))
+		}
+	}
+	for _, name := range sortedKeys(p.histograms) {
+		fmt.Fprintf(&b, "# TYPE %s histogram\n", name)
+		series := p.histograms[name]
+		for _, labels := range sortedKeys(series) {
+			h := series[labels]
+			var cumulative uint64
+			for i, bound := range h.bounds {
+				cumulative += h.counts[i]
+				fmt.Fprintf(&b, "%s_bucket%s %d\n", name, withPrometheusLabel(labels, "le", formatPrometheusValue(bound)), cumulative)
+			}
+			fmt.Fprintf(&b, "%s_bucket%s %d\n", name, withPrometheusLabel(labels, "le", "+Inf"), h.count)
+			fmt.Fprintf(&b, "%s_sum%s %s\n", name, labels, formatPrometheusValue(h.sum))
+			fmt.Fprintf(&b, "%s_count%s %d\n", name, labels, h.count)
+		}
+	}
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+func clonePrometheusBuckets(buckets map[string][]float64) map[string][]float64 {
+	clone := make(map[string][]float64, len(buckets))
+	for name, bounds := range buckets {
+		clone[name] = slices.Clone(bounds)
+	}
+	return clone
+}
+
+// formatPrometheusLabels formats labels as {name="value",...}, sorted by name, or "" if there are none.
+func formatPrometheusLabels(labels []Label) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	sorted := append([]Label(nil), labels...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	parts := make([]string, len(sorted))
+	for i, l := range sorted {
+		parts[i] = l.Name + `="` + prometheusLabelEscaper.Replace(l.Value) + `"`
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+var prometheusLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// withPrometheusLabel adds a label to formatted labels.
+func withPrometheusLabel(labels, name, value string) string {
+	l := name + `="` + prometheusLabelEscaper.Replace(value) + `"`
+	if labels == "" {
+		return "{" + l + "}"
+	}
+	return labels[:len(labels)-1] + "," + l + "}"
+}
+
+func formatPrometheusValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
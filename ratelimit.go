@@ -0,0 +1,183 @@
+package nursys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultRetryAfter is how long a RateLimiter pauses an endpoint answered with 429 Too Many Requests
+// without a Retry-After header.
+const DefaultRetryAfter = time.Second
+
+// RateLimit is a token bucket limit: Rate requests per second on average, with bursts of up to Burst
+// requests. A zero Rate means no limit.
+type RateLimit struct {
+	Rate  float64
+	Burst int // Treated as 1 if less than 1.
+}
+
+// RateLimiterConfig configures a RateLimiter.
+type RateLimiterConfig struct {
+	Default     RateLimit            // Limit of each endpoint not listed in Endpoints.
+	Endpoints   map[string]RateLimit // Limits by endpoint name, see the Endpoint constants.
+	MaxInFlight int                  // Maximum number of concurrent requests across all endpoints; 0 means no limit.
+}
+
+// RateLimiter limits the requests of one or more clients with a token bucket per endpoint and a global cap
+// on concurrent requests. It adapts to the server: an endpoint answered with 429 Too Many Requests, or with a
+// Retry-After header, is paused for the requested time and its rate is halved, then recovers gradually as
+// requests succeed. A RateLimiter is safe for concurrent use and can be shared by several clients to limit
+// them together.
+type RateLimiter struct {
+	cfg      RateLimiterConfig
+	inFlight chan struct{} // Semaphore, nil if unlimited.
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+// NewRateLimiter creates a RateLimiter.
+func NewRateLimiter(cfg RateLimiterConfig) *RateLimiter {
+	l := &RateLimiter{cfg: cfg, buckets: make(map[string]*tokenBucket)}
+	if cfg.MaxInFlight > 0 {
+		l.inFlight = make(chan struct{}, cfg.MaxInFlight)
+	}
+	return l
+}
+
+// WithRateLimit makes the client wait for limiter before every HTTP request. Waiting is abandoned with an
+// error when ctx is done, or right away if the wait would outlast the deadline of ctx.
+func WithRateLimit(limiter *RateLimiter) ClientOption {
+	return func(c *nsHTTPClient) {
+		c.limiter = limiter
+	}
+}
+
+type tokenBucket struct {
+	limit       RateLimit // Configured limit.
+	rate        float64   // Current rate, lowered while the server pushes back.
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+func (l *RateLimiter) bucket(endpoint string) *tokenBucket {
+	b, ok := l.buckets[endpoint]
+	if !ok {
+		limit, ok := l.cfg.Endpoints[endpoint]
+		if !ok {
+			limit = l.cfg.Default
+		}
+		if limit.Burst < 1 {
+			limit.Burst = 1
+		}
+		b = &tokenBucket{limit: limit, rate: limit.Rate, tokens: float64(limit.Burst), last: time.Now()}
+		l.buckets[endpoint] = b
+	}
+	return b
+}
+
+// reserve takes a token from the bucket of endpoint and returns how long to wait before using it.
+func (l *RateLimiter) reserve(endpoint string, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b := l.bucket(endpoint)
+	var wait time.Duration
+	if b.rate > 0 {
+		b.tokens = min(float64(b.limit.Burst), b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+		b.tokens--
+		if b.tokens < 0 {
+			wait = time.Duration(-b.tokens / b.rate * float64(time.Second))
+		}
+	}
+	return max(wait, b.pausedUntil.Sub(now))
+}
+
+// cancel returns a reserved token that was not used.
+func (l *RateLimiter) cancel(endpoint string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if b := l.bucket(endpoint); b.rate > 0 {
+		b.tokens++
+	}
+}
+
+// wait blocks until a request to endpoint may be sent. The returned function must be called once the
+// request is done.
+func (l *RateLimiter) wait(ctx context.Context, endpoint string) (func(), error) {
+	if wait := l.reserve(endpoint, time.Now()); wait > 0 {
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			l.cancel(endpoint)
+			return nil, fmt.Errorf("nursys: rate limit wait of %v for %s exceeds the context deadline: %w", wait, endpoint, context.DeadlineExceeded)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			l.cancel(endpoint)
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if l.inFlight == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.inFlight <- struct{}{}:
+		return func() { <-l.inFlight }, nil
+	case <-ctx.Done():
+		l.cancel(endpoint)
+		return nil, ctx.Err()
+	}
+}
+
+// observe adapts the limit of endpoint to the outcome of a request.
+func (l *RateLimiter) observe(endpoint string, err error) {
+	var statusErr *StatusError
+	var retryAfter time.Duration
+	pushback := false
+	if errors.As(err, &statusErr) {
+		retryAfter, pushback = parseRetryAfter(statusErr.Header.Get("Retry-After"), time.Now())
+		if statusErr.StatusCode == http.StatusTooManyRequests {
+			pushback = true
+			if retryAfter == 0 {
+				retryAfter = DefaultRetryAfter
+			}
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b := l.bucket(endpoint)
+	if !pushback {
+		if err == nil && b.rate < b.limit.Rate {
+			b.rate = min(b.limit.Rate, b.rate+b.limit.Rate/10)
+		}
+		return
+	}
+	if until := time.Now().Add(retryAfter); until.After(b.pausedUntil) {
+		b.pausedUntil = until
+	}
+	// Halve the rate, but not below a sixteenth of the configured rate.
+	b.rate = max(b.rate/2, b.limit.Rate/16)
+}
+
+// parseRetryAfter parses a Retry-After header value, either a number of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,157 @@
+package nursys_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/connectRN/go-nursys"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithRateLimit_TokenBucket(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write(submitResponseJSON)
+	}))
+	t.Cleanup(server.Close)
+
+	limiter := nursys.NewRateLimiter(nursys.RateLimiterConfig{
+		Endpoints: map[string]nursys.RateLimit{nursys.EndpointRetrieveDocuments: {Rate: 20, Burst: 1}},
+	})
+	client := nursys.New(server.URL, "acme", "1234!", nursys.WithRateLimit(limiter))
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := client.RetrieveDocuments(ctx, []string{"doc"})
+		require.NoError(t, err)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+
+	// Other endpoints are not limited.
+	start = time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := client.GetNurseLookupResult(ctx, "tx")
+		require.NoError(t, err)
+	}
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+
+	// A wait beyond the context deadline fails right away.
+	short, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err := client.RetrieveDocuments(short, []string{"doc"})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func Test_WithRateLimit_MaxInFlight(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		rw.Write(submitResponseJSON)
+	}))
+	t.Cleanup(server.Close)
+
+	limiter := nursys.NewRateLimiter(nursys.RateLimiterConfig{MaxInFlight: 2})
+	// Clients sharing a limiter are limited together.
+	clients := []nursys.Client{
+		nursys.New(server.URL, "acme", "1234!", nursys.WithRateLimit(limiter)),
+		nursys.New(server.URL, "other", "1234!", nursys.WithRateLimit(limiter)),
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(c nursys.Client) {
+			defer wg.Done()
+			_, err := c.GetNurseLookupResult(context.Background(), "tx")
+			assert.NoError(t, err)
+		}(clients[i%2])
+	}
+	wg.Wait()
+	assert.Equal(t, int32(2), maxInFlight.Load())
+}
+
+func Test_WithRateLimit_CancelInFlightWait(t *testing.T) {
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/nurselookup" {
+			started <- struct{}{}
+			<-release
+		}
+		rw.Write(submitResponseJSON)
+	}))
+	t.Cleanup(server.Close)
+
+	limiter := nursys.NewRateLimiter(nursys.RateLimiterConfig{
+		Endpoints:   map[string]nursys.RateLimit{nursys.EndpointRetrieveDocuments: {Rate: 1, Burst: 1}},
+		MaxInFlight: 1,
+	})
+	client := nursys.New(server.URL, "acme", "1234!", nursys.WithRateLimit(limiter))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := client.GetNurseLookupResult(context.Background(), "tx")
+		assert.NoError(t, err)
+	}()
+	<-started
+
+	// The request takes the only token, then gives up waiting for the busy semaphore.
+	short, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.RetrieveDocuments(short, []string{"doc"})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	close(release)
+	<-done
+
+	// The token was returned, so the next request does not wait for a refill.
+	start := time.Now()
+	_, err = client.RetrieveDocuments(context.Background(), []string{"doc"})
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func Test_WithRateLimit_RetryAfter(t *testing.T) {
+	ctx := context.Background()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests++
+		rw.Header().Set("Retry-After", "1")
+		rw.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(server.Close)
+
+	client := nursys.New(server.URL, "acme", "1234!", nursys.WithRateLimit(nursys.NewRateLimiter(nursys.RateLimiterConfig{})))
+	_, err := client.NurseLookup(ctx, nursys.NurseLookupSubmitRequestMessage{})
+	var statusErr *nursys.StatusError
+	require.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusTooManyRequests, statusErr.StatusCode)
+	assert.Equal(t, "nursys: request returned 429: ", err.Error())
+
+	// The endpoint is paused for a second, which does not fit in the deadline.
+	short, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	_, err = client.NurseLookup(short, nursys.NurseLookupSubmitRequestMessage{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, requests)
+
+	// Other endpoints are not paused.
+	_, err = client.NotificationLookup(short, nursys.NotificationLookupSubmitRequestMessage{})
+	assert.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, 2, requests)
+}
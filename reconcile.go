@@ -0,0 +1,291 @@
+package nursys
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultBatchSize is the number of nurses submitted per ManageNurseList request when no batch size is given.
+const DefaultBatchSize = 100
+
+// LicenseKey identifies the license a ManageNurseListRequest refers to, built from the jurisdiction,
+// license type and license number, or the NCSBN ID if there is no license number. Two requests with
+// the same key refer to the same entry on the institution’s nurse list.
+func (r ManageNurseListRequest) LicenseKey() string {
+	r = r.normalizeKey()
+	id := ""
+	if r.LicenseNumber == "" {
+		id = r.NcsbnID
+	}
+	return strings.Join([]string{string(r.JurisdictionAbbreviation), string(r.LicenseType), r.LicenseNumber, id}, "|")
+}
+
+// normalizeKey returns r with the fields of its LicenseKey in the form compared by LicenseKey.
+func (r ManageNurseListRequest) normalizeKey() ManageNurseListRequest {
+	r.JurisdictionAbbreviation = Jurisdiction(strings.ToUpper(strings.TrimSpace(string(r.JurisdictionAbbreviation))))
+	r.LicenseType = LicenseType(strings.ToUpper(strings.TrimSpace(string(r.LicenseType))))
+	r.LicenseNumber = strings.TrimSpace(r.LicenseNumber)
+	r.NcsbnID = strings.TrimSpace(r.NcsbnID)
+	return r
+}
+
+// ReconcileAction is a single row of a ReconcilePlan.
+type ReconcileAction struct {
+	Request       ManageNurseListRequest // Row to submit, with SubmissionActionCode set.
+	ChangedFields []string               // For updates of enrolled nurses, the names of the fields that differ. Empty for new and removed nurses.
+}
+
+// ReconcilePlan lists the ManageNurseList submissions needed to make the Nursys nurse list match a desired roster.
+type ReconcilePlan struct {
+	Adds      []ReconcileAction // Nurses to add (new) or update (changed fields) with ActionCodeAdd.
+	Removes   []ReconcileAction // Nurses to remove with ActionCodeRemove.
+	Unchanged int               // Number of desired nurses already enrolled with identical settings.
+}
+
+// Reconcile compares the desired roster against the last known enrolled set and returns the plan needed
+// to make them match. Records are matched by LicenseKey; if the same key appears more than once in a
+// set, the last occurrence wins. A record left unmatched because only one side has a license number
+// is then matched by jurisdiction, license type and NCSBN ID. A different NCSBN ID, or a license
+// number added, is an update of the matched record rather than a removal and a new addition.
+// SubmissionActionCode is ignored on input.
+func Reconcile(desired, enrolled []ManageNurseListRequest) ReconcilePlan {
+	want := indexByLicenseKey(desired)
+	have := indexByLicenseKey(enrolled)
+	matched := matchByNcsbnID(want, have)
+
+	var plan ReconcilePlan
+	for _, key := range sortedKeys(want) {
+		req := want[key]
+		req.SubmissionActionCode = ActionCodeAdd
+		prev, ok := have[key]
+		if !ok {
+			prev, ok = have[matched[key]]
+		}
+		if !ok {
+			plan.Adds = append(plan.Adds, ReconcileAction{Request: req})
+			continue
+		}
+		prev.SubmissionActionCode = ActionCodeAdd
+		if changed := changedFields(prev, req); len(changed) > 0 {
+			plan.Adds = append(plan.Adds, ReconcileAction{Request: req, ChangedFields: changed})
+		} else {
+			plan.Unchanged++
+		}
+	}
+	matchedHave := make(map[string]bool, len(matched))
+	for _, key := range matched {
+		matchedHave[key] = true
+	}
+	for _, key := range sortedKeys(have) {
+		if _, ok := want[key]; ok || matchedHave[key] {
+			continue
+		}
+		req := have[key]
+		req.SubmissionActionCode = ActionCodeRemove
+		plan.Removes = append(plan.Removes, ReconcileAction{Request: req})
+	}
+	return plan
+}
+
+// Empty reports whether the plan has nothing to submit.
+func (p ReconcilePlan) Empty() bool {
+	return len(p.Adds) == 0 && len(p.Removes) == 0
+}
+
+// Batches splits the plan into ManageNurseList submissions of at most batchSize nurses each.
+// Removals are submitted before additions. A batchSize of zero or less means DefaultBatchSize.
+func (p ReconcilePlan) Batches(batchSize int) []ManageNurseListSubmitRequestMessage {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	rows := make([]ManageNurseListRequest, 0, len(p.Removes)+len(p.Adds))
+	for _, a := range p.Removes {
+		rows = append(rows, a.Request)
+	}
+	for _, a := range p.Adds {
+		rows = append(rows, a.Request)
+	}
+	var batches []ManageNurseListSubmitRequestMessage
+	for len(rows) > 0 {
+		n := min(batchSize, len(rows))
+		batches = append(batches, ManageNurseListSubmitRequestMessage{ManageNurseListRequests: rows[:n:n]})
+		rows = rows[n:]
+	}
+	return batches
+}
+
+// Report writes a human readable dry-run description of the plan to w.
+func (p ReconcilePlan) Report(w io.Writer) error {
+	for _, a := range p.Adds {
+		what := "new"
+		if len(a.ChangedFields) > 0 {
+			what = "changed " + strings.Join(a.ChangedFields, ", ")
+		}
+		if _, err := fmt.Fprintf(w, "add     %s (%s)\n", describeRequest(a.Request), what); err != nil {
+			return err
+		}
+	}
+	for _, a := range p.Removes {
+		if _, err := fmt.Fprintf(w, "remove  %s\n", describeRequest(a.Request)); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%d to add or update, %d to remove, %d unchanged\n", len(p.Adds), len(p.Removes), p.Unchanged)
+	return err
+}
+
+// Apply submits the plan in batches of batchSize through ManageNurseList and polls each transaction
+// every pollInterval until processing is complete. Results are returned in submission order.
+// Apply stops at the first error, returning the results gathered so far. A submission or result whose
+// TransactionSuccessFlag is false is a *TransactionFailedError, and a result with nurses that were not
+// processed successfully is a *NurseListRowsFailedError; the failed result is included in the results.
+func (p ReconcilePlan) Apply(ctx context.Context, c Client, batchSize int, pollInterval time.Duration) (results []ManageNurseListRetrieveResponseMessage, err error) {
+	ctx, span := StartOperation(ctx, c, "nursys.ReconcilePlan.Apply")
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.End()
+	}()
+	for _, batch := range p.Batches(batchSize) {
+		submitted, err := c.ManageNurseList(ctx, batch)
+		if err != nil {
+			return results, err
+		}
+		if err := submitted.Transaction.Err(); err != nil {
+			return results, err
+		}
+		result, err := PollManageNurseListResult(ctx, c, submitted.TransactionID, pollInterval)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, result)
+		if err := result.Transaction.Err(); err != nil {
+			return results, err
+		}
+		if err := result.rowsErr(); err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+// NurseListRowsFailedError is returned by ReconcilePlan.Apply when a processed transaction reports nurses
+// that were not added, updated or removed.
+type NurseListRowsFailedError struct {
+	TransactionID string
+	Failed        []MangeNurseListResponse // Responses with SuccessFlag false.
+}
+
+// Error implements the error interface.
+func (e *NurseListRowsFailedError) Error() string {
+	msg := fmt.Sprintf("nursys: transaction %s: %d nurses failed", e.TransactionID, len(e.Failed))
+	for i, f := range e.Failed {
+		if i == 0 {
+			msg += ":"
+		} else {
+			msg += ";"
+		}
+		msg += " " + describeRequest(f.ManageNurseListRequest)
+		for _, te := range f.Errors {
+			msg += fmt.Sprintf(" (%d %s)", te.ErrorID, strings.TrimSpace(te.ErrorMessage))
+		}
+	}
+	return msg
+}
+
+// rowsErr returns a *NurseListRowsFailedError if any nurse of the result failed, and nil otherwise.
+func (m ManageNurseListRetrieveResponseMessage) rowsErr() error {
+	var failed []MangeNurseListResponse
+	for _, r := range m.ManageNurseListResponses {
+		if !r.SuccessFlag {
+			failed = append(failed, r)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return &NurseListRowsFailedError{TransactionID: m.Transaction.TransactionID, Failed: failed}
+}
+
+func indexByLicenseKey(requests []ManageNurseListRequest) map[string]ManageNurseListRequest {
+	index := make(map[string]ManageNurseListRequest, len(requests))
+	for _, r := range requests {
+		index[r.LicenseKey()] = r
+	}
+	return index
+}
+
+// matchByNcsbnID pairs the desired and enrolled records without a record of the same LicenseKey on the
+// other side that have the same jurisdiction, license type and NCSBN ID, and of which at most one has a
+// license number. It returns the enrolled key by
+// desired key.
+func matchByNcsbnID(want, have map[string]ManageNurseListRequest) map[string]string {
+	ncsbnKey := func(r ManageNurseListRequest) string {
+		r = r.normalizeKey()
+		if r.NcsbnID == "" {
+			return ""
+		}
+		return strings.Join([]string{string(r.JurisdictionAbbreviation), string(r.LicenseType), r.NcsbnID}, "|")
+	}
+	unmatched := make(map[string]string)
+	for _, key := range sortedKeys(have) {
+		if _, ok := want[key]; ok {
+			continue
+		}
+		if k := ncsbnKey(have[key]); k != "" {
+			unmatched[k] = key
+		}
+	}
+	matched := make(map[string]string)
+	for _, key := range sortedKeys(want) {
+		if _, ok := have[key]; ok {
+			continue
+		}
+		// Records that both have a license number are different licenses.
+		k := ncsbnKey(want[key])
+		if haveKey, ok := unmatched[k]; ok && k != "" && (want[key].normalizeKey().LicenseNumber == "" || have[haveKey].normalizeKey().LicenseNumber == "") {
+			matched[key] = haveKey
+			delete(unmatched, k)
+		}
+	}
+	return matched
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// changedFields returns the names of the fields that differ between a and b. The fields of the LicenseKey
+// are compared the way LicenseKey compares them.
+func changedFields(a, b ManageNurseListRequest) []string {
+	va, vb := reflect.ValueOf(a.normalizeKey()), reflect.ValueOf(b.normalizeKey())
+	var changed []string
+	for i := 0; i < va.NumField(); i++ {
+		if va.Field(i).Interface() != vb.Field(i).Interface() {
+			changed = append(changed, va.Type().Field(i).Name)
+		}
+	}
+	return changed
+}
+
+func describeRequest(r ManageNurseListRequest) string {
+	s := strings.TrimSpace(strings.Join([]string{string(r.JurisdictionAbbreviation), string(r.LicenseType), r.LicenseNumber}, " "))
+	if r.NcsbnID != "" {
+		s += " NCSBN " + r.NcsbnID
+	}
+	if r.RecordID != "" {
+		s += " RecordId " + r.RecordID
+	}
+	return s
+}
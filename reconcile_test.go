@@ -0,0 +1,199 @@
+package nursys_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/connectRN/go-nursys"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testNurse(number string) nursys.ManageNurseListRequest {
+	return nursys.ManageNurseListRequest{
+		JurisdictionAbbreviation: "NY",
+		LicenseType:              nursys.LicenseTypeRN,
+		LicenseNumber:            number,
+		Address1:                 "1 Main St",
+		City:                     "Albany",
+		State:                    "NY",
+		Zip:                      "12207",
+		LastFourSSN:              "1234",
+		BirthYear:                "1980",
+		NotificationsEnabled:     "Y",
+		RemindersEnabled:         "Y",
+	}
+}
+
+func Test_Reconcile(t *testing.T) {
+	assert := assert.New(t)
+
+	same, changed, added, removed := testNurse("1"), testNurse("2"), testNurse("3"), testNurse("4")
+	changedNow := changed
+	changedNow.Zip = "12208"
+	changedNow.SubmissionActionCode = nursys.ActionCodeAdd // Ignored on input
+
+	plan := nursys.Reconcile(
+		[]nursys.ManageNurseListRequest{same, changedNow, added},
+		[]nursys.ManageNurseListRequest{same, changed, removed},
+	)
+
+	changedNow.SubmissionActionCode = nursys.ActionCodeAdd
+	added.SubmissionActionCode = nursys.ActionCodeAdd
+	removed.SubmissionActionCode = nursys.ActionCodeRemove
+	assert.Equal([]nursys.ReconcileAction{
+		{Request: changedNow, ChangedFields: []string{"Zip"}},
+		{Request: added},
+	}, plan.Adds)
+	assert.Equal([]nursys.ReconcileAction{{Request: removed}}, plan.Removes)
+	assert.Equal(1, plan.Unchanged)
+
+	batches := plan.Batches(2)
+	require.Len(t, batches, 2)
+	assert.Equal([]nursys.ManageNurseListRequest{removed, changedNow}, batches[0].ManageNurseListRequests)
+	assert.Equal([]nursys.ManageNurseListRequest{added}, batches[1].ManageNurseListRequests)
+
+	var report bytes.Buffer
+	require.NoError(t, plan.Report(&report))
+	assert.Equal("add     NY RN 2 (changed Zip)\n"+
+		"add     NY RN 3 (new)\n"+
+		"remove  NY RN 4\n"+
+		"2 to add or update, 1 to remove, 1 unchanged\n", report.String())
+}
+
+func Test_ReconcilePlan_Apply(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+
+	var submitted []nursys.ManageNurseListSubmitRequestMessage
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodPost:
+			assert.Equal("/managenurselist", req.URL.String())
+			var msg nursys.ManageNurseListSubmitRequestMessage
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&msg))
+			submitted = append(submitted, msg)
+			rw.Write(submitResponseJSON)
+		case http.MethodGet:
+			assert.Equal("/managenurselist?transactionId=a523e0d4-01e1-4c8d-8dd9-54b269c315b7", req.URL.String())
+			polls++
+			json.NewEncoder(rw).Encode(nursys.ManageNurseListRetrieveResponseMessage{
+				ProcessingCompleteFlag: polls%2 == 0,
+				Transaction:            nursys.Transaction{TransactionSuccessFlag: true},
+			})
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	plan := nursys.Reconcile([]nursys.ManageNurseListRequest{testNurse("1"), testNurse("2")}, nil)
+	results, err := plan.Apply(ctx, nursys.New(server.URL, "acme", "1234!"), 1, time.Millisecond)
+	require.NoError(t, err)
+	assert.Len(results, 2)
+	assert.Len(submitted, 2)
+	assert.Equal(4, polls)
+}
+
+func Test_Reconcile_KeyFieldCase(t *testing.T) {
+	enrolled := testNurse("1")
+	desired := enrolled
+	desired.JurisdictionAbbreviation = "ny"
+	desired.LicenseType = " rn"
+	desired.LicenseNumber = "1 "
+
+	plan := nursys.Reconcile([]nursys.ManageNurseListRequest{desired}, []nursys.ManageNurseListRequest{enrolled})
+	assert.True(t, plan.Empty())
+	assert.Equal(t, 1, plan.Unchanged)
+}
+
+func Test_Reconcile_NcsbnID(t *testing.T) {
+	assert := assert.New(t)
+
+	// A roster row gaining an NCSBN ID is an update, not a removal and a new addition.
+	enrolled := testNurse("1")
+	desired := enrolled
+	desired.NcsbnID = "12345678"
+	plan := nursys.Reconcile([]nursys.ManageNurseListRequest{desired}, []nursys.ManageNurseListRequest{enrolled})
+	assert.Empty(plan.Removes)
+	require.Len(t, plan.Adds, 1)
+	assert.Equal([]string{"NcsbnID"}, plan.Adds[0].ChangedFields)
+
+	// A nurse enrolled by NCSBN ID is matched once the roster has the license number.
+	enrolled = testNurse("")
+	enrolled.NcsbnID = "12345678"
+	plan = nursys.Reconcile([]nursys.ManageNurseListRequest{desired}, []nursys.ManageNurseListRequest{enrolled})
+	assert.Empty(plan.Removes)
+	require.Len(t, plan.Adds, 1)
+	assert.Equal([]string{"LicenseNumber"}, plan.Adds[0].ChangedFields)
+
+	// Different license numbers are different licenses, even with the same NCSBN ID.
+	enrolled = testNurse("2")
+	enrolled.NcsbnID = "12345678"
+	plan = nursys.Reconcile([]nursys.ManageNurseListRequest{desired}, []nursys.ManageNurseListRequest{enrolled})
+	assert.Len(plan.Removes, 1)
+	require.Len(t, plan.Adds, 1)
+	assert.Empty(plan.Adds[0].ChangedFields)
+}
+
+func Test_ReconcilePlan_ApplyFailures(t *testing.T) {
+	ctx := context.Background()
+	plan := nursys.Reconcile([]nursys.ManageNurseListRequest{testNurse("1"), testNurse("2")}, nil)
+
+	for name, tc := range map[string]struct {
+		result   nursys.ManageNurseListRetrieveResponseMessage
+		checkErr func(t *testing.T, err error)
+	}{
+		"transaction": {
+			result: nursys.ManageNurseListRetrieveResponseMessage{
+				Transaction: nursys.Transaction{TransactionID: "tx-9", TransactionErrors: []nursys.TransactionError{{ErrorID: 500, ErrorMessage: "Internal error"}}},
+			},
+			checkErr: func(t *testing.T, err error) {
+				var txErr *nursys.TransactionFailedError
+				require.ErrorAs(t, err, &txErr)
+				assert.Equal(t, "tx-9", txErr.Transaction.TransactionID)
+			},
+		},
+		"rows": {
+			result: nursys.ManageNurseListRetrieveResponseMessage{
+				Transaction: nursys.Transaction{TransactionID: "tx-9", TransactionSuccessFlag: true},
+				ManageNurseListResponses: []nursys.MangeNurseListResponse{
+					{SuccessFlag: true, ManageNurseListRequest: testNurse("1")},
+					{Errors: []nursys.TransactionError{{ErrorID: 12, ErrorMessage: "Invalid birth year"}}, ManageNurseListRequest: testNurse("2")},
+				},
+			},
+			checkErr: func(t *testing.T, err error) {
+				var rowsErr *nursys.NurseListRowsFailedError
+				require.ErrorAs(t, err, &rowsErr)
+				require.Len(t, rowsErr.Failed, 1)
+				assert.Equal(t, "2", rowsErr.Failed[0].ManageNurseListRequest.LicenseNumber)
+				assert.EqualError(t, err, "nursys: transaction tx-9: 1 nurses failed: NY RN 2 (12 Invalid birth year)")
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			submissions := 0
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				if req.Method == http.MethodPost {
+					submissions++
+					rw.Write(submitResponseJSON)
+					return
+				}
+				result := tc.result
+				result.ProcessingCompleteFlag = true
+				json.NewEncoder(rw).Encode(result)
+			}))
+			t.Cleanup(server.Close)
+
+			results, err := plan.Apply(ctx, nursys.New(server.URL, "acme", "1234!"), 1, time.Millisecond)
+			tc.checkErr(t, err)
+			assert.Len(t, results, 1)
+			assert.Equal(t, 1, submissions)
+		})
+	}
+}
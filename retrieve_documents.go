@@ -14,7 +14,7 @@ import (
 func (c *nsHTTPClient) RetrieveDocuments(ctx context.Context, documentIDs []string) (RetrieveDocumentsRetrieveResponseMessage, error) {
 	var response RetrieveDocumentsRetrieveResponseMessage
 	ids := strings.Join(documentIDs, ",") // Nursys expects DocumentId values separated by commas
-	err := c.invokeEndpoint(ctx, "GET", "/retrievedocuments?documentIds="+url.QueryEscape(ids), nil, &response)
+	err := c.invoke(ctx, &Call{Endpoint: EndpointRetrieveDocuments, Method: "GET", Path: "/retrievedocuments?documentIds=" + url.QueryEscape(ids), Request: documentIDs, Response: &response})
 	return response, err
 }
 
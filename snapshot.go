@@ -0,0 +1,162 @@
+package nursys
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrSnapshotNotFound is returned by SnapshotStore queries when no snapshot matches.
+var ErrSnapshotNotFound = errors.New("nursys: snapshot not found")
+
+// NurseLookupSnapshot is a Nurse Lookup response as it was retrieved at a point in time.
+type NurseLookupSnapshot struct {
+	NcsbnID       string              `json:"NcsbnId"`
+	RetrievedAt   time.Time           `json:"RetrievedAt"`
+	TransactionID string              `json:"TransactionId"` // Nurse Lookup transaction the response was retrieved from.
+	Response      NurseLookupResponse `json:"Response"`
+}
+
+// SnapshotStore keeps the history of Nurse Lookup responses per NCSBN ID. Snapshots are never modified
+// or removed once saved, so the store records what was known about a nurse at any point in time.
+// Implementations must be safe for concurrent use.
+type SnapshotStore interface {
+	// Save adds a snapshot to the history of its NcsbnID.
+	Save(ctx context.Context, snapshot NurseLookupSnapshot) error
+	// Latest returns the most recently retrieved snapshot of the nurse.
+	Latest(ctx context.Context, ncsbnID string) (NurseLookupSnapshot, error)
+	// AsOf returns the most recent snapshot of the nurse retrieved at or before t.
+	AsOf(ctx context.Context, ncsbnID string, t time.Time) (NurseLookupSnapshot, error)
+	// History returns all snapshots of the nurse, oldest first. It returns an empty history, not
+	// ErrSnapshotNotFound, for unknown nurses.
+	History(ctx context.Context, ncsbnID string) ([]NurseLookupSnapshot, error)
+}
+
+// SaveNurseLookupResult saves a snapshot of every successful response with an NCSBN ID in a completed
+// Nurse Lookup result, as retrieved at retrievedAt. It stops at the first error.
+func SaveNurseLookupResult(ctx context.Context, store SnapshotStore, result NurseLookupRetrieveResponseMessage, retrievedAt time.Time) error {
+	for _, r := range result.NurseLookupResponses {
+		if !r.SuccessFlag || r.NcsbnID == "" {
+			continue
+		}
+		err := store.Save(ctx, NurseLookupSnapshot{
+			NcsbnID:       r.NcsbnID,
+			RetrievedAt:   retrievedAt,
+			TransactionID: result.Transaction.TransactionID,
+			Response:      r,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FileSnapshotStore is a SnapshotStore keeping one JSON file per NCSBN ID in a directory. It is safe for
+// concurrent use within a process. It should not be shared between processes.
+type FileSnapshotStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// OpenFileSnapshotStore opens the snapshot store in dir, creating the directory if needed.
+func OpenFileSnapshotStore(dir string) (*FileSnapshotStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileSnapshotStore{dir: dir}, nil
+}
+
+// Save implements SnapshotStore.
+func (s *FileSnapshotStore) Save(_ context.Context, snapshot NurseLookupSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	history, err := s.load(snapshot.NcsbnID)
+	if err != nil {
+		return err
+	}
+	history = append(history, snapshot)
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].RetrievedAt.Before(history[j].RetrievedAt)
+	})
+	b, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path(snapshot.NcsbnID), b)
+}
+
+// Latest implements SnapshotStore.
+func (s *FileSnapshotStore) Latest(ctx context.Context, ncsbnID string) (NurseLookupSnapshot, error) {
+	history, err := s.History(ctx, ncsbnID)
+	if err != nil {
+		return NurseLookupSnapshot{}, err
+	}
+	if len(history) == 0 {
+		return NurseLookupSnapshot{}, ErrSnapshotNotFound
+	}
+	return history[len(history)-1], nil
+}
+
+// AsOf implements SnapshotStore.
+func (s *FileSnapshotStore) AsOf(ctx context.Context, ncsbnID string, t time.Time) (NurseLookupSnapshot, error) {
+	history, err := s.History(ctx, ncsbnID)
+	if err != nil {
+		return NurseLookupSnapshot{}, err
+	}
+	i := sort.Search(len(history), func(i int) bool { return history[i].RetrievedAt.After(t) })
+	if i == 0 {
+		return NurseLookupSnapshot{}, ErrSnapshotNotFound
+	}
+	return history[i-1], nil
+}
+
+// History implements SnapshotStore.
+func (s *FileSnapshotStore) History(_ context.Context, ncsbnID string) ([]NurseLookupSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load(ncsbnID)
+}
+
+// load reads the history of a nurse. The caller must hold s.mu.
+func (s *FileSnapshotStore) load(ncsbnID string) ([]NurseLookupSnapshot, error) {
+	if !validSnapshotID(ncsbnID) {
+		return nil, fmt.Errorf("nursys: invalid NCSBN ID %q", ncsbnID)
+	}
+	b, err := os.ReadFile(s.path(ncsbnID))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var history []NurseLookupSnapshot
+	if err := json.Unmarshal(b, &history); err != nil {
+		return nil, fmt.Errorf("nursys: reading snapshots of %s: %w", ncsbnID, err)
+	}
+	return history, nil
+}
+
+func (s *FileSnapshotStore) path(ncsbnID string) string {
+	return filepath.Join(s.dir, ncsbnID+".json")
+}
+
+// validSnapshotID reports whether id can safely be used as a file name.
+func validSnapshotID(id string) bool {
+	return id != "" && len(id) <= 10 && isAlphanumeric(id)
+}
+
+func isAlphanumeric(s string) bool {
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') {
+			return false
+		}
+	}
+	return true
+}
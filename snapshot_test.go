@@ -0,0 +1,69 @@
+package nursys_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/connectRN/go-nursys"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FileSnapshotStore(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+	dir := filepath.Join(t.TempDir(), "snapshots")
+
+	store, err := nursys.OpenFileSnapshotStore(dir)
+	require.NoError(t, err)
+
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 12, 0, 0, 0, time.UTC) }
+	result := func(txID, status string) nursys.NurseLookupRetrieveResponseMessage {
+		return nursys.NurseLookupRetrieveResponseMessage{
+			Transaction: nursys.Transaction{TransactionID: txID},
+			NurseLookupResponses: []nursys.NurseLookupResponse{
+				{SuccessFlag: true, NcsbnID: "12345", NurseLookupLicenses: []nursys.NurseLookupLicense{{LicenseStatus: status}}},
+				{SuccessFlag: false, NcsbnID: "99999"},
+				{SuccessFlag: true},
+			},
+		}
+	}
+	// Saved out of order on purpose.
+	require.NoError(t, nursys.SaveNurseLookupResult(ctx, store, result("tx-8", "Encumbered"), day(8)))
+	require.NoError(t, nursys.SaveNurseLookupResult(ctx, store, result("tx-1", "Active"), day(1)))
+
+	// A reopened store sees the same history.
+	store, err = nursys.OpenFileSnapshotStore(dir)
+	require.NoError(t, err)
+
+	history, err := store.History(ctx, "12345")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal("tx-1", history[0].TransactionID)
+	assert.Equal("tx-8", history[1].TransactionID)
+
+	latest, err := store.Latest(ctx, "12345")
+	require.NoError(t, err)
+	assert.Equal("Encumbered", latest.Response.NurseLookupLicenses[0].LicenseStatus)
+
+	asOf, err := store.AsOf(ctx, "12345", day(5))
+	require.NoError(t, err)
+	assert.Equal("tx-1", asOf.TransactionID)
+	asOf, err = store.AsOf(ctx, "12345", day(8))
+	require.NoError(t, err)
+	assert.Equal("tx-8", asOf.TransactionID)
+
+	_, err = store.AsOf(ctx, "12345", day(1).Add(-time.Second))
+	assert.ErrorIs(err, nursys.ErrSnapshotNotFound)
+	_, err = store.Latest(ctx, "99999")
+	assert.ErrorIs(err, nursys.ErrSnapshotNotFound)
+	history, err = store.History(ctx, "99999")
+	assert.NoError(err)
+	assert.Empty(history)
+
+	_, err = store.History(ctx, "../12345")
+	assert.Error(err)
+}
@@ -0,0 +1,100 @@
+package nursys
+
+import "context"
+
+// Span attribute keys set by the client.
+const (
+	AttrEndpoint       = "nursys.endpoint"        // One of the Endpoint constants.
+	AttrTransactionID  = "nursys.transaction_id"  // Transaction submitted or retrieved.
+	AttrBatchSize      = "nursys.batch_size"      // Number of nurses or documents in the request.
+	AttrPollIterations = "nursys.poll_iterations" // Result requests made by a Poll helper.
+	AttrHTTPMethod     = "http.method"
+	AttrHTTPStatus     = "http.status_code"
+)
+
+// Attribute is a span attribute. Values are strings, ints or bools.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Tracer starts spans. Adapters bind it to a tracing system; the parent span, if any, is carried by ctx
+// in the adapter's own way. Implementations must be safe for concurrent use.
+type Tracer interface {
+	// Start starts a span as a child of the span in ctx, returning a context carrying the new span.
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// Span is an operation being traced.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// NoopTracer is a Tracer whose spans do nothing. It is the default of clients created without WithTracer.
+type NoopTracer struct{}
+
+// Start implements Tracer.
+func (NoopTracer) Start(ctx context.Context, _ string, _ ...Attribute) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...Attribute) {}
+func (noopSpan) RecordError(error)          {}
+func (noopSpan) End()                       {}
+
+// WithTracer makes the client start a span, named "nursys." followed by the endpoint name, for every HTTP
+// request. The span is a child of the span in the request context, such as one started with StartOperation.
+func WithTracer(tracer Tracer) ClientOption {
+	return func(c *nsHTTPClient) {
+		c.tracer = tracer
+	}
+}
+
+// tracerProvider is implemented by clients that may have a tracer.
+type tracerProvider interface {
+	tracerOf() Tracer
+}
+
+func (c *nsHTTPClient) tracerOf() Tracer {
+	return c.tracer
+}
+
+// StartOperation starts a span for a logical operation made of several calls to c, such as a submission
+// followed by polling for its result, using the tracer of c. The calls made with the returned context are
+// traced as children of the operation span. The span is a no-op if c has no tracer.
+func StartOperation(ctx context.Context, c Client, name string, attrs ...Attribute) (context.Context, Span) {
+	if p, ok := c.(tracerProvider); ok && p.tracerOf() != nil {
+		return p.tracerOf().Start(ctx, name, attrs...)
+	}
+	return NoopTracer{}.Start(ctx, name, attrs...)
+}
+
+// traceCall starts the span of an HTTP request. The returned function ends it with the outcome of the request.
+func (c *nsHTTPClient) traceCall(ctx context.Context, call *Call) (context.Context, func(status int, err error)) {
+	if c.tracer == nil {
+		return ctx, func(int, error) {}
+	}
+	attrs := []Attribute{{AttrEndpoint, call.Endpoint}, {AttrHTTPMethod, call.Method}}
+	if n, ok := batchSize(call.Request); ok {
+		attrs = append(attrs, Attribute{AttrBatchSize, n})
+	}
+	if txID, ok := call.Request.(string); ok {
+		attrs = append(attrs, Attribute{AttrTransactionID, txID})
+	}
+	ctx, span := c.tracer.Start(ctx, "nursys."+call.Endpoint, attrs...)
+	return ctx, func(status int, err error) {
+		if status != 0 {
+			span.SetAttributes(Attribute{AttrHTTPStatus, status})
+		}
+		if err != nil {
+			span.RecordError(err)
+		} else if tx, ok := transactionOf(call.Response); ok && tx.TransactionID != "" {
+			span.SetAttributes(Attribute{AttrTransactionID, tx.TransactionID})
+		}
+		span.End()
+	}
+}
@@ -0,0 +1,111 @@
+package nursys_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/connectRN/go-nursys"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSpan struct {
+	name   string
+	parent *testSpan
+	attrs  map[string]interface{}
+	err    error
+	ended  bool
+}
+
+func (s *testSpan) SetAttributes(attrs ...nursys.Attribute) {
+	for _, a := range attrs {
+		s.attrs[a.Key] = a.Value
+	}
+}
+func (s *testSpan) RecordError(err error) { s.err = err }
+func (s *testSpan) End()                  { s.ended = true }
+
+type testSpanKey struct{}
+
+type testTracer struct {
+	mu    sync.Mutex
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(ctx context.Context, name string, attrs ...nursys.Attribute) (context.Context, nursys.Span) {
+	parent, _ := ctx.Value(testSpanKey{}).(*testSpan)
+	span := &testSpan{name: name, parent: parent, attrs: map[string]interface{}{}}
+	span.SetAttributes(attrs...)
+	t.mu.Lock()
+	t.spans = append(t.spans, span)
+	t.mu.Unlock()
+	return context.WithValue(ctx, testSpanKey{}, span), span
+}
+
+func Test_WithTracer(t *testing.T) {
+	assert := assert.New(t)
+
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodPost {
+			rw.Write(submitResponseJSON)
+			return
+		}
+		polls++
+		if polls == 1 {
+			rw.Write([]byte(`{"ProcessingCompleteFlag": false}`))
+		} else {
+			rw.Write([]byte(`{"ProcessingCompleteFlag": true}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	tracer := &testTracer{}
+	client := nursys.New(server.URL, "acme", "1234!", nursys.WithTracer(tracer))
+
+	ctx, op := nursys.StartOperation(context.Background(), client, "lookup")
+	submitted, err := client.NurseLookup(ctx, nursys.NurseLookupSubmitRequestMessage{
+		NurseLookupRequests: []nursys.NurseLookupRequest{{NcsbnID: "1"}, {NcsbnID: "2"}},
+	})
+	require.NoError(t, err)
+	_, err = nursys.PollNurseLookupResult(ctx, client, submitted.TransactionID, time.Millisecond)
+	require.NoError(t, err)
+	op.End()
+
+	require.Len(t, tracer.spans, 5)
+	root, submit, pollSpan, get1, get2 := tracer.spans[0], tracer.spans[1], tracer.spans[2], tracer.spans[3], tracer.spans[4]
+	for _, s := range tracer.spans {
+		assert.True(s.ended, s.name)
+	}
+
+	assert.Equal("lookup", root.name)
+	assert.Nil(root.parent)
+
+	assert.Equal("nursys.NurseLookup", submit.name)
+	assert.Same(root, submit.parent)
+	assert.Equal(nursys.EndpointNurseLookup, submit.attrs[nursys.AttrEndpoint])
+	assert.Equal(2, submit.attrs[nursys.AttrBatchSize])
+	assert.Equal(200, submit.attrs[nursys.AttrHTTPStatus])
+	assert.Equal(submitted.TransactionID, submit.attrs[nursys.AttrTransactionID])
+
+	assert.Equal("nursys.Poll", pollSpan.name)
+	assert.Same(root, pollSpan.parent)
+	assert.Equal(2, pollSpan.attrs[nursys.AttrPollIterations])
+	assert.Equal(submitted.TransactionID, pollSpan.attrs[nursys.AttrTransactionID])
+
+	for _, get := range []*testSpan{get1, get2} {
+		assert.Equal("nursys.GetNurseLookupResult", get.name)
+		assert.Same(pollSpan, get.parent)
+		assert.Equal(submitted.TransactionID, get.attrs[nursys.AttrTransactionID])
+	}
+
+	// Without a tracer, operations are no-ops.
+	ctx, span := nursys.StartOperation(context.Background(), nursys.New(server.URL, "acme", "1234!"), "lookup")
+	assert.NotNil(ctx)
+	span.End()
+}
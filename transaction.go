@@ -1,5 +1,10 @@
 package nursys
 
+import (
+	"fmt"
+	"strings"
+)
+
 // The Transaction JSON object contains information about the API request and response.
 type Transaction struct {
 	TransactionID          string             `json:"TransactionId"`          // Required Unique transaction identifier. Used as an input for the Manage Nurse List HTTP GET method.
@@ -14,3 +19,30 @@ type TransactionError struct {
 	ErrorID      int64  `json:"ErrorID"`      // System assigned processing error identifier.
 	ErrorMessage string `json:"ErrorMessage"` // System provided processing error message
 }
+
+// Err returns a *TransactionFailedError if the transaction was not successfully processed, and nil otherwise.
+func (t Transaction) Err() error {
+	if t.TransactionSuccessFlag {
+		return nil
+	}
+	return &TransactionFailedError{Transaction: t}
+}
+
+// TransactionFailedError is returned when the API accepted a request but reported TransactionSuccessFlag false.
+type TransactionFailedError struct {
+	Transaction Transaction
+}
+
+// Error implements the error interface.
+func (e *TransactionFailedError) Error() string {
+	msg := "nursys: transaction " + e.Transaction.TransactionID + " failed"
+	for i, te := range e.Transaction.TransactionErrors {
+		if i == 0 {
+			msg += ":"
+		} else {
+			msg += ";"
+		}
+		msg += fmt.Sprintf(" %d %s", te.ErrorID, strings.TrimSpace(te.ErrorMessage))
+	}
+	return msg
+}
@@ -0,0 +1,92 @@
+package nursys
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DefaultTimeout is the time limit of a request, including reading the response, of clients created
+// without WithTimeout or WithHTTPClient.
+const DefaultTimeout = 60 * time.Second
+
+// transportConfig holds the connection options used to build the http.Client of a client.
+type transportConfig struct {
+	timeout       time.Duration
+	proxy         *url.URL // nil means the proxy from the environment (HTTPS_PROXY etc.).
+	rootCAs       *x509.CertPool
+	certificates  []tls.Certificate
+	minTLSVersion uint16
+}
+
+// WithTimeout sets the time limit of a request, including reading the response. Zero means no limit.
+// The default is DefaultTimeout.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *nsHTTPClient) {
+		c.transport.timeout = timeout
+	}
+}
+
+// WithProxy sends requests through the proxy at proxyURL, such as "http://proxy.example.com:3128",
+// instead of the proxy configured by the HTTPS_PROXY and NO_PROXY environment variables.
+// An invalid URL fails every request, or NewClient.
+func WithProxy(proxyURL string) ClientOption {
+	return func(c *nsHTTPClient) {
+		u, err := url.Parse(proxyURL)
+		if err == nil && (u.Scheme == "" || u.Host == "") {
+			err = fmt.Errorf("must be an absolute URL")
+		}
+		if err != nil {
+			c.setConfigErr(fmt.Errorf("nursys: invalid proxy URL %q: %w", proxyURL, err))
+			return
+		}
+		c.transport.proxy = u
+	}
+}
+
+// WithRootCAs verifies the server certificate against the certificate authorities in pool instead of
+// the system roots.
+func WithRootCAs(pool *x509.CertPool) ClientOption {
+	return func(c *nsHTTPClient) {
+		c.transport.rootCAs = pool
+	}
+}
+
+// WithClientCertificate presents cert to servers that request a client certificate.
+// It can be given more than once.
+func WithClientCertificate(cert tls.Certificate) ClientOption {
+	return func(c *nsHTTPClient) {
+		c.transport.certificates = append(c.transport.certificates, cert)
+	}
+}
+
+// WithMinTLSVersion sets the minimum TLS version, such as tls.VersionTLS13. The default is TLS 1.2.
+func WithMinTLSVersion(version uint16) ClientOption {
+	return func(c *nsHTTPClient) {
+		c.transport.minTLSVersion = version
+	}
+}
+
+// setConfigErr records the first configuration error.
+func (c *nsHTTPClient) setConfigErr(err error) {
+	if c.configErr == nil {
+		c.configErr = err
+	}
+}
+
+// newHTTPClient builds an http.Client from the connection options.
+func (cfg transportConfig) newHTTPClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.proxy != nil {
+		transport.Proxy = http.ProxyURL(cfg.proxy)
+	}
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:      cfg.rootCAs,
+		Certificates: cfg.certificates,
+		MinVersion:   cfg.minTLSVersion,
+	}
+	return &http.Client{Transport: transport, Timeout: cfg.timeout}
+}
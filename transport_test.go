@@ -0,0 +1,94 @@
+package nursys_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/connectRN/go-nursys"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		select {
+		case <-release:
+		case <-req.Context().Done():
+		}
+		rw.Write(submitResponseJSON)
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+
+	client := nursys.New(server.URL, "acme", "1234!", nursys.WithTimeout(50*time.Millisecond))
+	start := time.Now()
+	_, err := client.GetNurseLookupResult(context.Background(), "tx")
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func Test_Proxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		proxied = append(proxied, req.URL.String())
+		rw.Write(submitResponseJSON)
+	}))
+	t.Cleanup(proxy.Close)
+
+	client, err := nursys.NewClient("http://nursys.invalid/", "acme", "1234!", nursys.WithProxy(proxy.URL))
+	require.NoError(t, err)
+	_, err = client.GetNurseLookupResult(context.Background(), "tx")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"http://nursys.invalid/nurselookup?transactionId=tx"}, proxied)
+}
+
+func Test_InvalidProxy(t *testing.T) {
+	for _, proxyURL := range []string{"", "proxy:3128", "http://%zz"} {
+		_, err := nursys.NewClient("https://api.nursys.test/", "acme", "1234!", nursys.WithProxy(proxyURL))
+		assert.ErrorContains(t, err, "invalid proxy URL", proxyURL)
+	}
+
+	// The base URL error is reported first.
+	_, err := nursys.NewClient("api.nursys.test", "acme", "1234!", nursys.WithProxy("proxy:3128"))
+	assert.ErrorContains(t, err, "invalid base URL")
+}
+
+func Test_RootCAs(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write(submitResponseJSON)
+	}))
+	t.Cleanup(server.Close)
+
+	// The test server certificate is not trusted by the system roots.
+	_, err := nursys.New(server.URL, "acme", "1234!").GetNurseLookupResult(context.Background(), "tx")
+	require.Error(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	_, err = nursys.New(server.URL, "acme", "1234!", nursys.WithRootCAs(pool)).GetNurseLookupResult(context.Background(), "tx")
+	require.NoError(t, err)
+}
+
+func Test_MinTLSVersion(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write(submitResponseJSON)
+	}))
+	server.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	_, err := nursys.New(server.URL, "acme", "1234!", nursys.WithRootCAs(pool)).GetNurseLookupResult(context.Background(), "tx")
+	require.NoError(t, err)
+	_, err = nursys.New(server.URL, "acme", "1234!", nursys.WithRootCAs(pool), nursys.WithMinTLSVersion(tls.VersionTLS13)).
+		GetNurseLookupResult(context.Background(), "tx")
+	require.Error(t, err)
+}
@@ -0,0 +1,79 @@
+package nursys
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Environment is a named Nursys API environment.
+type Environment string
+
+// Environments
+const (
+	EnvironmentProduction Environment = "production"
+	EnvironmentTest       Environment = "test" // Test/sandbox environment for integration testing.
+)
+
+// environmentURLs are the base URLs of the environments. Nursys confirms the URLs to use along with the
+// API credentials of an institution.
+var environmentURLs = map[Environment]string{
+	EnvironmentProduction: "https://api.nursys.com/",
+	EnvironmentTest:       "https://uat-api.nursys.com/",
+}
+
+// ParseEnvironment parses an environment name, ignoring case. "prod", "sandbox" and "uat" are accepted
+// as aliases.
+func ParseEnvironment(s string) (Environment, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "production", "prod":
+		return EnvironmentProduction, nil
+	case "test", "sandbox", "uat":
+		return EnvironmentTest, nil
+	}
+	return "", fmt.Errorf("nursys: unknown environment %q", s)
+}
+
+// BaseURL returns the base URL of the environment, or the empty string for an unknown environment.
+func (e Environment) BaseURL() string {
+	return environmentURLs[e]
+}
+
+// NewClient is like New, but returns an error if baseURL is not a valid absolute http or https URL
+// instead of failing every request.
+//
+//	client, err := nursys.NewClient(nursys.EnvironmentTest.BaseURL(), "username", "password")
+func NewClient(baseURL, username, password string, options ...ClientOption) (Client, error) {
+	c := New(baseURL, username, password, options...).(*nsHTTPClient)
+	if c.configErr != nil {
+		return nil, c.configErr
+	}
+	return c, nil
+}
+
+// parseBaseURL validates a client base URL.
+func parseBaseURL(baseURL string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(baseURL))
+	if err != nil {
+		return nil, fmt.Errorf("nursys: invalid base URL %q: %w", baseURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("nursys: invalid base URL %q: must be an absolute http or https URL", baseURL)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return nil, fmt.Errorf("nursys: invalid base URL %q: must not have a query or fragment", baseURL)
+	}
+	return u, nil
+}
+
+// endpointURL joins the base URL and an endpoint path with an optional query, such as
+// "/nurselookup?transactionId=1", with exactly one slash between them. An escaped base path, such
+// as "/a%2Fb", is kept as is.
+func endpointURL(base *url.URL, endpoint string) string {
+	path, query, _ := strings.Cut(endpoint, "?")
+	u := *base
+	u.Path = strings.TrimSuffix(base.Path, "/") + "/" + strings.TrimPrefix(path, "/")
+	u.RawPath = strings.TrimSuffix(base.EscapedPath(), "/") + "/" + strings.TrimPrefix(path, "/")
+	u.RawQuery = query
+	return u.String()
+}
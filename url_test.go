@@ -0,0 +1,69 @@
+package nursys_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/connectRN/go-nursys"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BaseURLJoining(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		paths = append(paths, req.URL.String())
+		rw.Write(submitResponseJSON)
+	}))
+	t.Cleanup(server.Close)
+
+	for _, base := range []string{server.URL, server.URL + "/", server.URL + "/api", server.URL + "/api/", server.URL + "/a%2Fb/"} {
+		client, err := nursys.NewClient(base, "acme", "1234!")
+		require.NoError(t, err, base)
+		_, err = client.GetNurseLookupResult(context.Background(), "tx 1")
+		require.NoError(t, err, base)
+	}
+	assert.Equal(t, []string{
+		"/nurselookup?transactionId=tx+1",
+		"/nurselookup?transactionId=tx+1",
+		"/api/nurselookup?transactionId=tx+1",
+		"/api/nurselookup?transactionId=tx+1",
+		"/a%2Fb/nurselookup?transactionId=tx+1",
+	}, paths)
+}
+
+func Test_NewClient_InvalidBaseURL(t *testing.T) {
+	for _, base := range []string{"", "api.nursys.com", "ftp://api.nursys.com", "https://", "https://api.nursys.com/?x=1", "http://[::1"} {
+		_, err := nursys.NewClient(base, "acme", "1234!")
+		assert.Error(t, err, base)
+
+		// New defers the error to the first request.
+		_, err = nursys.New(base, "acme", "1234!").NurseLookup(context.Background(), nursys.NurseLookupSubmitRequestMessage{})
+		assert.ErrorContains(t, err, "invalid base URL", base)
+	}
+}
+
+func Test_ParseEnvironment(t *testing.T) {
+	for input, want := range map[string]nursys.Environment{
+		"production": nursys.EnvironmentProduction,
+		" PROD ":     nursys.EnvironmentProduction,
+		"test":       nursys.EnvironmentTest,
+		"Sandbox":    nursys.EnvironmentTest,
+		"uat":        nursys.EnvironmentTest,
+		"staging":    "",
+	} {
+		env, err := nursys.ParseEnvironment(input)
+		if want == "" {
+			assert.Error(t, err, input)
+			continue
+		}
+		require.NoError(t, err, input)
+		assert.Equal(t, want, env, input)
+		_, err = nursys.NewClient(env.BaseURL(), "acme", "1234!")
+		assert.NoError(t, err, input)
+	}
+	assert.Empty(t, nursys.Environment("staging").BaseURL())
+}